@@ -153,7 +153,8 @@ func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
 
-	warnings, err := c.Validate(ctx, otelcol)
+	// The config isn't validated on deletion, so that a collector whose config became invalid can still be deleted.
+	warnings, err := c.validate(ctx, otelcol, false)
 	if err != nil {
 		return warnings, err
 	}
@@ -166,11 +167,26 @@ func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object
 }
 
 func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollector) (admission.Warnings, error) {
+	return c.validate(ctx, r, true)
+}
+
+func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollector, validateConfig bool) (admission.Warnings, error) {
 	warnings := admission.Warnings{}
 
-	nullObjects := r.Spec.Config.nullObjects()
-	if len(nullObjects) > 0 {
-		warnings = append(warnings, fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", ")))
+	if validateConfig {
+		nullObjects := r.Spec.Config.nullObjects()
+		if len(nullObjects) > 0 {
+			warnings = append(warnings, fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", ")))
+		}
+
+		warnings = append(warnings, r.Spec.Config.Warnings()...)
+
+		// validate the collector configuration itself
+		configWarnings, err := validateCollectorConfig(c.logger, &r.Spec.Config)
+		warnings = append(warnings, configWarnings...)
+		if err != nil {
+			return warnings, err
+		}
 	}
 
 	// validate volumeClaimTemplates
@@ -369,6 +385,35 @@ func ValidateProbe(probeName string, probe *Probe) error {
 	return nil
 }
 
+// validateCollectorConfig rejects the collector configs known to prevent the collector from starting: one binding the
+// telemetry metrics port twice, requiring different values for an environment variable of the collector's container,
+// listing a receiver or an exporter twice in a pipeline, or having an invalid component ID or pipeline name. All the
+// other problems Config.Validate finds, including those of the components' own configs, are only advisory and returned
+// as warnings, as its checks may reject configs the collector accepts.
+func validateCollectorConfig(logger logr.Logger, cfg *Config) (admission.Warnings, error) {
+	if err := cfg.validateTelemetryPort(logger); err != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector configuration is incorrect: %w", err)
+	}
+	if err := cfg.ValidateEnvironmentVariables(logger); err != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector configuration is incorrect: %w", err)
+	}
+	if _, err := cfg.ValidatePipelineProcessorUniqueness(); err != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector configuration is incorrect: %w", err)
+	}
+	if err := cfg.ValidateIDs(); err != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector configuration is incorrect: %w", err)
+	}
+	err := cfg.Validate()
+	if err == nil {
+		return nil, nil
+	}
+	var warnings admission.Warnings
+	for _, problem := range strings.Split(err.Error(), "\n") {
+		warnings = append(warnings, fmt.Sprintf("the OpenTelemetry Collector configuration may be incorrect: %s", problem))
+	}
+	return warnings, nil
+}
+
 func ValidatePorts(ports []PortsSpec) error {
 	for _, p := range ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -1362,6 +1362,117 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
+		{
+			name: "telemetry port conflicting with an exporter port",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: v1beta1.Config{
+						Exporters: v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"prometheus": map[string]interface{}{
+									"endpoint": "0.0.0.0:8888",
+								},
+							},
+						},
+						Service: v1beta1.Service{
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"metrics": {
+									Processors: []string{"batch"},
+									Exporters:  []string{"prometheus"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector configuration is incorrect: the telemetry metrics port 8888 conflicts",
+		},
+		{
+			name: "receiver listed twice in a pipeline",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"otlp": map[string]interface{}{},
+							},
+						},
+						Exporters: v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"otlp": map[string]interface{}{"endpoint": "backend:4317"},
+							},
+						},
+						Processors: &v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"batch": map[string]interface{}{},
+							},
+						},
+						Service: v1beta1.Service{
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"traces": {
+									Receivers:  []string{"otlp", "otlp"},
+									Processors: []string{"batch"},
+									Exporters:  []string{"otlp"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector configuration is incorrect: pipeline traces: receiver otlp is listed more than once",
+		},
+		{
+			name: "invalid component ID",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: v1beta1.Config{
+						Exporters: v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"debug/": map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: `the OpenTelemetry Collector configuration is incorrect: exporter ID "debug/" is invalid`,
+		},
+		{
+			name: "advisory config problem reported as a warning",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: v1beta1.Config{
+						Extensions: &v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"ack": map[string]interface{}{"storage": "file_storage"},
+							},
+						},
+						Service: v1beta1.Service{
+							Extensions: []string{"ack"},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"the OpenTelemetry Collector configuration may be incorrect: extension ack depends on extension file_storage, set in storage, which isn't enabled",
+			},
+		},
+		{
+			name: "orphan connector warning",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: v1beta1.Config{
+						Connectors: &v1beta1.AnyConfig{
+							Object: map[string]interface{}{
+								"forward": map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"connectors defined but not used by any pipeline: forward",
+			},
+		},
 	}
 
 	bv := func(_ context.Context, collector v1beta1.OpenTelemetryCollector) admission.Warnings {
@@ -1411,6 +1522,47 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColValidateDeleteWebhook(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Exporters: v1beta1.AnyConfig{
+					Object: map[string]interface{}{
+						"prometheus": map[string]interface{}{
+							"endpoint": "0.0.0.0:8888",
+						},
+					},
+				},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"metrics": {
+							Exporters: []string{"prometheus"},
+						},
+					},
+				},
+			},
+		},
+	}
+	cvw := v1beta1.NewCollectorWebhook(
+		logr.Discard(),
+		testScheme,
+		config.New(
+			config.WithCollectorImage("collector:v0.0.0"),
+			config.WithTargetAllocatorImage("ta:v0.0.0"),
+		),
+		getReviewer(false),
+		nil,
+		nil,
+		nil,
+	)
+
+	_, err := cvw.ValidateCreate(context.Background(), &otelcol)
+	require.ErrorContains(t, err, "the telemetry metrics port 8888 conflicts")
+	warnings, err := cvw.ValidateDelete(context.Background(), &otelcol)
+	assert.NoError(t, err, "a collector with an invalid config must still be deletable")
+	assert.Empty(t, warnings)
+}
+
 func TestOTELColValidateUpdateWebhook(t *testing.T) {
 	tests := []struct { //nolint:govet
 		name             string
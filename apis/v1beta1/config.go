@@ -8,11 +8,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"reflect"
-	"regexp"
+	"slices"
 	"sort"
-	"strconv"
 	"strings"
+	"text/template"
 
 	"dario.cat/mergo"
 	"github.com/go-logr/logr"
@@ -21,10 +23,12 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/connectors"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/exporters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/extensions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 type ComponentKind int
@@ -34,10 +38,22 @@ const (
 	KindExporter
 	KindProcessor
 	KindExtension
+	KindConnector
 )
 
 func (c ComponentKind) String() string {
-	return [...]string{"receiver", "exporter", "processor", "extension"}[c]
+	return [...]string{"receiver", "exporter", "processor", "extension", "connector"}[c]
+}
+
+// AllComponentKinds returns every kind of components, in the order data flows through a pipeline, followed by the
+// extensions and the connectors. Code meaning every kind should use it, so that it picks up new kinds.
+func AllComponentKinds() []ComponentKind {
+	return []ComponentKind{KindReceiver, KindProcessor, KindExporter, KindExtension, KindConnector}
+}
+
+// IsValid reports whether the kind is one of the known kinds of components.
+func (c ComponentKind) IsValid() bool {
+	return slices.Contains(AllComponentKinds(), c)
 }
 
 // AnyConfig represent parts of the config.
@@ -67,6 +83,34 @@ func (c *AnyConfig) DeepCopy() *AnyConfig {
 	return out
 }
 
+// deepCopyMap returns a copy of the given map, recursively copying the nested maps and slices.
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = deepCopyValue(v)
+	}
+	return out
+}
+
+// deepCopyValue returns a copy of the given value, recursively copying the nested maps and slices.
+func deepCopyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = deepCopyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
 var _ json.Marshaler = &AnyConfig{}
 var _ json.Unmarshaler = &AnyConfig{}
 
@@ -102,6 +146,7 @@ func (c *Config) GetEnabledComponents() map[ComponentKind]map[string]interface{}
 		KindProcessor: {},
 		KindExporter:  {},
 		KindExtension: {},
+		KindConnector: {},
 	}
 	for _, extension := range c.Service.Extensions {
 		toReturn[KindExtension][extension] = struct{}{}
@@ -120,6 +165,14 @@ func (c *Config) GetEnabledComponents() map[ComponentKind]map[string]interface{}
 		for _, componentId := range pipeline.Processors {
 			toReturn[KindProcessor][componentId] = struct{}{}
 		}
+		// Connectors are used as an exporter in a pipeline and as a receiver in another.
+		if c.Connectors != nil {
+			for _, componentId := range slices.Concat(pipeline.Receivers, pipeline.Exporters) {
+				if _, ok := c.Connectors.Object[componentId]; ok {
+					toReturn[KindConnector][componentId] = struct{}{}
+				}
+			}
+		}
 	}
 	for _, componentId := range c.Service.Extensions {
 		toReturn[KindExtension][componentId] = struct{}{}
@@ -127,6 +180,37 @@ func (c *Config) GetEnabledComponents() map[ComponentKind]map[string]interface{}
 	return toReturn
 }
 
+// OrderedServiceExtensions returns the extensions enabled in the service in the order they're declared, which is the
+// order the collector starts them in. Duplicates are only returned once.
+func (c *Config) OrderedServiceExtensions() []string {
+	seen := map[string]struct{}{}
+	var ordered []string
+	for _, extension := range c.Service.Extensions {
+		if _, ok := seen[extension]; ok {
+			continue
+		}
+		seen[extension] = struct{}{}
+		ordered = append(ordered, extension)
+	}
+	return ordered
+}
+
+// ServiceExtensionConfigs returns the configuration of every extension enabled in the service, by extension ID. An
+// extension without configuration, or with one that isn't a map, has a nil configuration. An error is returned if an
+// enabled extension isn't defined.
+func (c *Config) ServiceExtensionConfigs() (map[string]map[string]interface{}, error) {
+	defined := c.ComponentsOfKind(KindExtension)
+	configs := make(map[string]map[string]interface{}, len(c.Service.Extensions))
+	for _, extension := range c.Service.Extensions {
+		cfg, ok := defined[extension]
+		if !ok {
+			return nil, fmt.Errorf("extension %s is enabled but not defined", extension)
+		}
+		configs[extension] = cfg
+	}
+	return configs, nil
+}
+
 // Config encapsulates collector config.
 type Config struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -142,34 +226,123 @@ type Config struct {
 	Service    Service    `json:"service" yaml:"service"`
 }
 
+// topLevelKeys holds the keys a config may have at its top level.
+var topLevelKeys = []string{"receivers", "exporters", "processors", "connectors", "extensions", "service"}
+
+// ConfigFromYAMLStrict parses a config from YAML, rejecting the keys at the top level of the config that aren't
+// component sections or the service, such as a misspelled "recievers", which would otherwise be silently ignored. The
+// content of the sections isn't checked, as components accept arbitrary fields.
+func ConfigFromYAMLStrict(data []byte) (*Config, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) > 0 {
+		root := document.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: config must be a mapping", root.Line)
+		}
+		var errs []error
+		for i := 0; i < len(root.Content); i += 2 {
+			key := root.Content[i]
+			if !slices.Contains(topLevelKeys, key.Value) {
+				errs = append(errs, fmt.Errorf("line %d: unknown top-level key %q, must be one of: %s",
+					key.Line, key.Value, strings.Join(topLevelKeys, ", ")))
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+	c := &Config{}
+	if err := document.Decode(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RenderConfigTemplate executes the given Go text/template with the given data, then parses the result with
+// ConfigFromYAMLStrict and validates it. A template referencing a missing key of the data fails rather than rendering
+// "<no value>". The errors carry the line they were found on, in the template or in the rendered config.
+func RenderConfigTemplate(tmpl string, data interface{}) (*Config, error) {
+	t, err := template.New("config").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config template: %w", err)
+	}
+	var rendered bytes.Buffer
+	if err := t.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render the config template: %w", err)
+	}
+	c, err := ConfigFromYAMLStrict(rendered.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid rendered config: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rendered config: %w", err)
+	}
+	return c, nil
+}
+
+// RetrieverSet holds the parser retriever of each kind of components.
+// +kubebuilder:object:generate=false
+type RetrieverSet struct {
+	Receivers  components.ParserRetriever
+	Exporters  components.ParserRetriever
+	Processors components.ParserRetriever
+	Extensions components.ParserRetriever
+	Connectors components.ParserRetriever
+}
+
+// For returns the parser retriever for the given kind of components.
+func (r RetrieverSet) For(kind ComponentKind) components.ParserRetriever {
+	switch kind {
+	case KindReceiver:
+		return r.Receivers
+	case KindExporter:
+		return r.Exporters
+	case KindProcessor:
+		return r.Processors
+	case KindExtension:
+		return r.Extensions
+	case KindConnector:
+		return r.Connectors
+	}
+	return nil
+}
+
+// parserRetrievers are the retrievers used to get the parsers of the components of a config.
+var parserRetrievers = RetrieverSet{
+	Receivers:  receivers.ReceiverFor,
+	Exporters:  exporters.ParserFor,
+	Processors: processors.ProcessorFor,
+	Extensions: extensions.ParserFor,
+	Connectors: connectors.ParserFor,
+}
+
+// componentConfigs returns the definitions of the given kind of components, which is nil if the optional section
+// isn't set.
+func (c *Config) componentConfigs(kind ComponentKind) map[string]interface{} {
+	if section := c.componentSection(kind); section != nil {
+		return section.Object
+	}
+	return nil
+}
+
 // getRbacRulesForComponentKinds gets the RBAC Rules for the given ComponentKind(s).
 func (c *Config) getRbacRulesForComponentKinds(logger logr.Logger, componentKinds ...ComponentKind) ([]rbacv1.PolicyRule, error) {
 	var rules []rbacv1.PolicyRule
 	enabledComponents := c.GetEnabledComponents()
 	for _, componentKind := range componentKinds {
-		var retriever components.ParserRetriever
-		var cfg AnyConfig
 		switch componentKind {
-		case KindReceiver:
-			retriever = receivers.ReceiverFor
-			cfg = c.Receivers
-		case KindExporter:
-			retriever = exporters.ParserFor
-			cfg = c.Exporters
-		case KindProcessor:
-			retriever = processors.ProcessorFor
-			if c.Processors == nil {
-				cfg = AnyConfig{}
-			} else {
-				cfg = *c.Processors
-			}
+		case KindReceiver, KindExporter, KindProcessor, KindConnector:
 		case KindExtension:
 			continue
 		}
+		retriever := parserRetrievers.For(componentKind)
+		cfg := c.componentConfigs(componentKind)
 		for componentName := range enabledComponents[componentKind] {
-			// TODO: Clean up the naming here and make it simpler to use a retriever.
 			parser := retriever(componentName)
-			if parsedRules, err := parser.GetRBACRules(logger, cfg.Object[componentName]); err != nil {
+			if parsedRules, err := parser.GetRBACRules(logger, cfg[componentName]); err != nil {
 				return nil, err
 			} else {
 				rules = append(rules, parsedRules...)
@@ -179,46 +352,90 @@ func (c *Config) getRbacRulesForComponentKinds(logger logr.Logger, componentKind
 	return rules, nil
 }
 
-// getPortsForComponentKinds gets the ports for the given ComponentKind(s).
+// getPortsForComponentKinds gets the ports for the given ComponentKind(s). The ports are named as GetAllPorts names
+// them, whatever the kinds asked for.
 func (c *Config) getPortsForComponentKinds(logger logr.Logger, componentKinds ...ComponentKind) ([]corev1.ServicePort, error) {
+	allPorts, owners, err := c.ownedPorts(logger)
+	if err != nil {
+		return nil, err
+	}
 	var ports []corev1.ServicePort
+	for i, port := range allPorts {
+		if slices.Contains(componentKinds, owners[i].kind) {
+			ports = append(ports, port)
+		}
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports, nil
+}
+
+// portOwner identifies the component producing a port.
+// +kubebuilder:object:generate=false
+type portOwner struct {
+	kind ComponentKind
+	id   string
+}
+
+// ownedPorts returns the ports of all the enabled components, along with the component producing each, the names of
+// the ports being disambiguated across all of them.
+func (c *Config) ownedPorts(logger logr.Logger) ([]corev1.ServicePort, []portOwner, error) {
+	var ports []corev1.ServicePort
+	var owners []portOwner
 	enabledComponents := c.GetEnabledComponents()
-	for _, componentKind := range componentKinds {
-		var retriever components.ParserRetriever
-		var cfg AnyConfig
+	for _, componentKind := range AllComponentKinds() {
 		switch componentKind {
-		case KindReceiver:
-			retriever = receivers.ReceiverFor
-			cfg = c.Receivers
-		case KindExporter:
-			retriever = exporters.ParserFor
-			cfg = c.Exporters
+		case KindReceiver, KindExporter, KindExtension, KindConnector:
 		case KindProcessor:
 			continue
-		case KindExtension:
-			retriever = extensions.ParserFor
-			if c.Extensions == nil {
-				cfg = AnyConfig{}
-			} else {
-				cfg = *c.Extensions
-			}
 		}
+		retriever := parserRetrievers.For(componentKind)
+		cfg := c.componentConfigs(componentKind)
 		for componentName := range enabledComponents[componentKind] {
-			// TODO: Clean up the naming here and make it simpler to use a retriever.
 			parser := retriever(componentName)
-			if parsedPorts, err := parser.Ports(logger, componentName, cfg.Object[componentName]); err != nil {
-				return nil, err
+			if parsedPorts, err := parser.Ports(logger, componentName, cfg[componentName]); err != nil {
+				return nil, nil, err
 			} else {
 				ports = append(ports, parsedPorts...)
+				for range parsedPorts {
+					owners = append(owners, portOwner{kind: componentKind, id: componentName})
+				}
 			}
 		}
 	}
+	disambiguatePortNames(ports, owners)
+	return ports, owners, nil
+}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
-
-	return ports, nil
+// disambiguatePortNames renames the ports whose name is produced by more than one component, as the Service can't be
+// created with duplicate port names. The name is prefixed with the base type of the component producing the port, or
+// with the component's full ID if another component of the same type produces it too.
+func disambiguatePortNames(ports []corev1.ServicePort, owners []portOwner) {
+	ownersByName := map[string]map[portOwner]struct{}{}
+	for i, port := range ports {
+		if ownersByName[port.Name] == nil {
+			ownersByName[port.Name] = map[portOwner]struct{}{}
+		}
+		ownersByName[port.Name][owners[i]] = struct{}{}
+	}
+	for i := range ports {
+		nameOwners := ownersByName[ports[i].Name]
+		if len(nameOwners) < 2 {
+			continue
+		}
+		ownerType := components.ComponentType(owners[i].id)
+		prefix := ownerType
+		for owner := range nameOwners {
+			if owner != owners[i] && components.ComponentType(owner.id) == ownerType {
+				prefix = owners[i].id
+				break
+			}
+		}
+		ports[i].Name = naming.PortName(fmt.Sprintf("%s-%s", prefix, ports[i].Name), ports[i].Port)
+	}
 }
 
 // getEnvironmentVariablesForComponentKinds gets the environment variables for the given ComponentKind(s).
@@ -226,23 +443,11 @@ func (c *Config) getEnvironmentVariablesForComponentKinds(logger logr.Logger, co
 	var envVars []corev1.EnvVar = []corev1.EnvVar{}
 	enabledComponents := c.GetEnabledComponents()
 	for _, componentKind := range componentKinds {
-		var retriever components.ParserRetriever
-		var cfg AnyConfig
-
-		switch componentKind {
-		case KindReceiver:
-			retriever = receivers.ReceiverFor
-			cfg = c.Receivers
-		case KindExporter:
-			continue
-		case KindProcessor:
-			continue
-		case KindExtension:
-			continue
-		}
+		retriever := parserRetrievers.For(componentKind)
+		cfg := c.componentConfigs(componentKind)
 		for componentName := range enabledComponents[componentKind] {
 			parser := retriever(componentName)
-			if parsedEnvVars, err := parser.GetEnvironmentVariables(logger, cfg.Object[componentName]); err != nil {
+			if parsedEnvVars, err := parser.GetEnvironmentVariables(logger, cfg[componentName]); err != nil {
 				return nil, err
 			} else {
 				envVars = append(envVars, parsedEnvVars...)
@@ -257,6 +462,27 @@ func (c *Config) getEnvironmentVariablesForComponentKinds(logger logr.Logger, co
 	return envVars, nil
 }
 
+// dedupEnvVars removes repeated environment variables from a list sorted by name. Several components may require the
+// same variable, but not different values under the same name: the names of such variables are returned.
+func dedupEnvVars(envVars []corev1.EnvVar) ([]corev1.EnvVar, []string) {
+	deduped := []corev1.EnvVar{}
+	var conflicts []string
+	for i := 0; i < len(envVars); {
+		j := i + 1
+		conflicting := false
+		for ; j < len(envVars) && envVars[j].Name == envVars[i].Name; j++ {
+			conflicting = conflicting || !reflect.DeepEqual(envVars[i], envVars[j])
+		}
+		if conflicting {
+			conflicts = append(conflicts, envVars[i].Name)
+		} else {
+			deduped = append(deduped, envVars[i])
+		}
+		i = j
+	}
+	return deduped, conflicts
+}
+
 // applyDefaultForComponentKinds applies defaults to the endpoints for the given ComponentKind(s).
 func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKinds ...ComponentKind) error {
 	if err := c.Service.ApplyDefaults(logger); err != nil {
@@ -264,22 +490,20 @@ func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKind
 	}
 	enabledComponents := c.GetEnabledComponents()
 	for _, componentKind := range componentKinds {
-		var retriever components.ParserRetriever
-		var cfg AnyConfig
 		switch componentKind {
-		case KindReceiver:
-			retriever = receivers.ReceiverFor
-			cfg = c.Receivers
-		case KindExporter:
-			continue
-		case KindProcessor:
+		case KindReceiver, KindConnector:
+		case KindExporter, KindProcessor, KindExtension:
 			continue
-		case KindExtension:
+		}
+		retriever := parserRetrievers.For(componentKind)
+		cfg := c.componentConfigs(componentKind)
+		if cfg == nil {
+			// Nothing to default, and nowhere to store defaults.
 			continue
 		}
 		for componentName := range enabledComponents[componentKind] {
 			parser := retriever(componentName)
-			componentConf := cfg.Object[componentName]
+			componentConf := cfg[componentName]
 			newCfg, err := parser.GetDefaultConfig(logger, componentConf)
 			if err != nil {
 				return err
@@ -288,6 +512,11 @@ func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKind
 			// We need to ensure we don't remove any fields in defaulting.
 			mappedCfg, ok := newCfg.(map[string]interface{})
 			if !ok || mappedCfg == nil {
+				// A scalar default can't be merged, it's only used when the user hasn't configured the component.
+				if !ok && newCfg != nil && componentConf == nil {
+					cfg[componentName] = newCfg
+					continue
+				}
 				logger.V(1).Info("returned default configuration invalid",
 					"warn", "could not apply component defaults",
 					"component", componentName,
@@ -301,7 +530,7 @@ func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKind
 			if err := mergo.Merge(&mappedCfg, componentConf); err != nil {
 				return err
 			}
-			cfg.Object[componentName] = mappedCfg
+			cfg[componentName] = mappedCfg
 		}
 	}
 
@@ -320,34 +549,220 @@ func (c *Config) GetExtensionPorts(logger logr.Logger) ([]corev1.ServicePort, er
 	return c.getPortsForComponentKinds(logger, KindExtension)
 }
 
+// ExtensionPorts returns the ports of the enabled extensions whose type is the given base type, whatever their name,
+// such as the port the health_check extension listens on. The ports are named as GetAllPorts names them, and sorted by
+// name.
+func (c *Config) ExtensionPorts(logger logr.Logger, baseType string) ([]corev1.ServicePort, error) {
+	allPorts, owners, err := c.ownedPorts(logger)
+	if err != nil {
+		return nil, err
+	}
+	var ports []corev1.ServicePort
+	for i, port := range allPorts {
+		if owners[i].kind == KindExtension && components.ComponentType(owners[i].id) == baseType {
+			ports = append(ports, port)
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+	return ports, nil
+}
+
 func (c *Config) GetReceiverAndExporterPorts(logger logr.Logger) ([]corev1.ServicePort, error) {
 	return c.getPortsForComponentKinds(logger, KindReceiver, KindExporter)
 }
 
 func (c *Config) GetAllPorts(logger logr.Logger) ([]corev1.ServicePort, error) {
-	return c.getPortsForComponentKinds(logger, KindReceiver, KindExporter, KindExtension)
+	return c.getPortsForComponentKinds(logger, KindReceiver, KindExporter, KindExtension, KindConnector)
+}
+
+// GetContainerPorts returns the container ports backing the ports returned by GetAllPorts, as returned by Ports.
+func (c *Config) GetContainerPorts(logger logr.Logger) ([]corev1.ContainerPort, error) {
+	_, containerPorts, err := c.Ports(logger)
+	return containerPorts, err
+}
+
+// Ports returns the ports of the Service, as returned by GetAllPorts, along with the container ports backing them,
+// computed once so that both stay consistent. Each container port has the name of its service port, and the number the
+// service port targets. Service ports sharing a target number and protocol are backed by a single container port,
+// keeping the first one by name.
+func (c *Config) Ports(logger logr.Logger) ([]corev1.ServicePort, []corev1.ContainerPort, error) {
+	servicePorts, err := c.GetAllPorts(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	var containerPorts []corev1.ContainerPort
+	seen := map[string]struct{}{}
+	for _, servicePort := range servicePorts {
+		containerPort := servicePort.Port
+		if servicePort.TargetPort.IntValue() > 0 {
+			containerPort = servicePort.TargetPort.IntVal
+		}
+		key := portKey(containerPort, servicePort.Protocol)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          servicePort.Name,
+			ContainerPort: containerPort,
+			Protocol:      servicePort.Protocol,
+		})
+	}
+	return servicePorts, containerPorts, nil
+}
+
+// PipelinePorts returns, by pipeline name, the ports of the receivers and of the exporters listening on a port, such as
+// the prometheus exporter, used by each pipeline, sorted by name. A port used by several pipelines is returned for each
+// of them. Connectors don't listen on ports and aren't considered.
+func (c *Config) PipelinePorts(logger logr.Logger) (map[string][]corev1.ServicePort, error) {
+	connectors := c.ComponentsOfKind(KindConnector)
+	componentPorts := map[ComponentKind]map[string][]corev1.ServicePort{
+		KindReceiver: {},
+		KindExporter: {},
+	}
+	pipelinePorts := map[string][]corev1.ServicePort{}
+	for _, name := range c.pipelineNames() {
+		pipeline := c.Service.Pipelines[name]
+		var ports []corev1.ServicePort
+		for _, kind := range []ComponentKind{KindReceiver, KindExporter} {
+			cfg := c.componentConfigs(kind)
+			for _, id := range pipeline.componentIDs(kind) {
+				if _, isConnector := connectors[id]; isConnector {
+					continue
+				}
+				parsedPorts, ok := componentPorts[kind][id]
+				if !ok {
+					var err error
+					if parsedPorts, err = parserRetrievers.For(kind)(id).Ports(logger, id, cfg[id]); err != nil {
+						return nil, err
+					}
+					componentPorts[kind][id] = parsedPorts
+				}
+				ports = append(ports, parsedPorts...)
+			}
+		}
+		sort.Slice(ports, func(i, j int) bool {
+			return ports[i].Name < ports[j].Name
+		})
+		pipelinePorts[name] = ports
+	}
+	return pipelinePorts, nil
+}
+
+// ReceiversWithoutPorts returns the IDs of the enabled receivers that don't listen on any port, such as filelog or
+// hostmetrics, sorted. Connectors used as receivers aren't reported.
+func (c *Config) ReceiversWithoutPorts(logger logr.Logger) ([]string, error) {
+	enabledComponents := c.GetEnabledComponents()
+	cfg := c.componentConfigs(KindReceiver)
+	var withoutPorts []string
+	for id := range enabledComponents[KindReceiver] {
+		if _, isConnector := enabledComponents[KindConnector][id]; isConnector {
+			continue
+		}
+		ports, err := parserRetrievers.Receivers(id).Ports(logger, id, cfg[id])
+		if err != nil {
+			return nil, err
+		}
+		if len(ports) == 0 {
+			withoutPorts = append(withoutPorts, id)
+		}
+	}
+	sort.Strings(withoutPorts)
+	return withoutPorts, nil
+}
+
+// PortRange returns the lowest and the highest of the ports the collector listens on, those of the enabled components
+// and the one it exposes its own metrics on. Ports only known at runtime, such as those set from environment
+// variables, are skipped. An error is returned if no port is known.
+func (c *Config) PortRange(logger logr.Logger) (int32, int32, error) {
+	endpoints, err := c.ListeningEndpoints(logger)
+	if err != nil {
+		return 0, 0, err
+	}
+	var ports []int32
+	for _, endpoint := range endpoints {
+		if endpoint.Port > 0 {
+			ports = append(ports, endpoint.Port)
+		}
+	}
+	if c.Service.MetricsEnabled() {
+		if telemetryPort, err := c.Service.MetricsPort(logger); err == nil {
+			ports = append(ports, telemetryPort)
+		}
+	}
+	if len(ports) == 0 {
+		return 0, 0, errors.New("no port could be resolved from the config")
+	}
+	return slices.Min(ports), slices.Max(ports), nil
 }
 
 func (c *Config) GetEnvironmentVariables(logger logr.Logger) ([]corev1.EnvVar, error) {
 	return c.getEnvironmentVariablesForComponentKinds(logger, KindReceiver)
 }
 
+// GetAllEnvironmentVariables gets the environment variables required by all the enabled components, each only once.
+// An error is returned if components require different values for the same variable, see
+// ValidateEnvironmentVariables.
+func (c *Config) GetAllEnvironmentVariables(logger logr.Logger) ([]corev1.EnvVar, error) {
+	envVars, err := c.getEnvironmentVariablesForComponentKinds(logger, AllComponentKinds()...)
+	if err != nil {
+		return nil, err
+	}
+	deduped, conflicts := dedupEnvVars(envVars)
+	if err := envVarConflictsError(conflicts); err != nil {
+		return nil, err
+	}
+	return deduped, nil
+}
+
 func (c *Config) GetAllRbacRules(logger logr.Logger) ([]rbacv1.PolicyRule, error) {
-	return c.getRbacRulesForComponentKinds(logger, KindReceiver, KindExporter, KindProcessor)
+	return c.getRbacRulesForComponentKinds(logger, KindReceiver, KindExporter, KindProcessor, KindConnector)
+}
+
+// RBACRulesForComponent gets the RBAC rules required by a single enabled component, which helps understanding where a
+// rule of the generated role comes from.
+func (c *Config) RBACRulesForComponent(logger logr.Logger, kind ComponentKind, id string) ([]rbacv1.PolicyRule, error) {
+	if _, ok := c.GetEnabledComponents()[kind][id]; !ok {
+		return nil, fmt.Errorf("%s %s is not enabled", kind, id)
+	}
+	return parserRetrievers.For(kind)(id).GetRBACRules(logger, c.componentConfigs(kind)[id])
+}
+
+// ComponentDefaults returns the configuration of the given component as its parser defaults it, such as the endpoints
+// the OTLP receiver listens on when they aren't set, without applying the defaults to the config. An error is returned
+// if the component isn't defined, or if its defaulted configuration isn't a map.
+func (c *Config) ComponentDefaults(logger logr.Logger, kind ComponentKind, id string) (map[string]interface{}, error) {
+	cfg, ok := c.componentConfigs(kind)[id]
+	if !ok {
+		return nil, fmt.Errorf("%s %s not found", kind, id)
+	}
+	defaulted, err := parserRetrievers.For(kind)(id).GetDefaultConfig(logger, deepCopyValue(cfg))
+	if err != nil {
+		return nil, err
+	}
+	if defaulted == nil {
+		return nil, nil
+	}
+	defaultedMap, ok := defaulted.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the defaults of %s %s are a %T, not a map", kind, id, defaulted)
+	}
+	return defaultedMap, nil
 }
 
 func (c *Config) ApplyDefaults(logger logr.Logger) error {
-	return c.applyDefaultForComponentKinds(logger, KindReceiver)
+	return c.applyDefaultForComponentKinds(logger, KindReceiver, KindConnector)
 }
 
-// GetLivenessProbe gets the first enabled liveness probe. There should only ever be one extension enabled
-// that provides the hinting for the liveness probe.
+// GetLivenessProbe gets the first enabled liveness probe. Only one enabled extension may provide the hinting for the
+// liveness probe, as enforced by ValidateExtensionProbeSingleton, extensions are checked in their declared order
+// otherwise.
 func (c *Config) GetLivenessProbe(logger logr.Logger) (*corev1.Probe, error) {
-	enabledComponents := c.GetEnabledComponents()
-	for componentName := range enabledComponents[KindExtension] {
-		// TODO: Clean up the naming here and make it simpler to use a retriever.
-		parser := extensions.ParserFor(componentName)
-		if probe, err := parser.GetLivenessProbe(logger, c.Extensions.Object[componentName]); err != nil {
+	for _, componentName := range c.OrderedServiceExtensions() {
+		parser := parserRetrievers.Extensions(componentName)
+		if probe, err := parser.GetLivenessProbe(logger, c.componentConfigs(KindExtension)[componentName]); err != nil {
 			return nil, err
 		} else if probe != nil {
 			return probe, nil
@@ -356,14 +771,13 @@ func (c *Config) GetLivenessProbe(logger logr.Logger) (*corev1.Probe, error) {
 	return nil, nil
 }
 
-// GetReadinessProbe gets the first enabled readiness probe. There should only ever be one extension enabled
-// that provides the hinting for the readiness probe.
+// GetReadinessProbe gets the first enabled readiness probe. Only one enabled extension may provide the hinting for the
+// readiness probe, as enforced by ValidateExtensionProbeSingleton, extensions are checked in their declared order
+// otherwise.
 func (c *Config) GetReadinessProbe(logger logr.Logger) (*corev1.Probe, error) {
-	enabledComponents := c.GetEnabledComponents()
-	for componentName := range enabledComponents[KindExtension] {
-		// TODO: Clean up the naming here and make it simpler to use a retriever.
-		parser := extensions.ParserFor(componentName)
-		if probe, err := parser.GetReadinessProbe(logger, c.Extensions.Object[componentName]); err != nil {
+	for _, componentName := range c.OrderedServiceExtensions() {
+		parser := parserRetrievers.Extensions(componentName)
+		if probe, err := parser.GetReadinessProbe(logger, c.componentConfigs(KindExtension)[componentName]); err != nil {
 			return nil, err
 		} else if probe != nil {
 			return probe, nil
@@ -372,6 +786,20 @@ func (c *Config) GetReadinessProbe(logger logr.Logger) (*corev1.Probe, error) {
 	return nil, nil
 }
 
+// ConfigFromMap builds a Config from an already decoded collector configuration, such as one embedded in another
+// resource. The map goes through the same JSON decoding the API server applies to the Config in a collector's spec.
+func ConfigFromMap(m map[string]interface{}) (*Config, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 // Yaml encodes the current object and returns it as a string.
 func (c *Config) Yaml() (string, error) {
 	var buf bytes.Buffer
@@ -383,7 +811,44 @@ func (c *Config) Yaml() (string, error) {
 	return buf.String(), nil
 }
 
-// Returns null objects in the config.
+// nodePortKey is the key of the endpoints' configs pinning the NodePort of their service port. It's only read by the
+// operator, the collector rejects it.
+const nodePortKey = "node_port"
+
+// Rendered returns a copy of the config as it's handed to the collector, without the keys only read by the operator.
+func (c *Config) Rendered() *Config {
+	rendered := c.Clone()
+	for _, section := range []*AnyConfig{&rendered.Receivers, &rendered.Exporters, rendered.Extensions} {
+		if section == nil {
+			continue
+		}
+		for _, componentConfig := range section.Object {
+			removeNodePorts(componentConfig)
+		}
+	}
+	return rendered
+}
+
+// removeNodePorts removes the node port from the given component config, and from each of its protocols' endpoints.
+func removeNodePorts(componentConfig interface{}) {
+	cfg, ok := componentConfig.(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(cfg, nodePortKey)
+	protocols, ok := cfg["protocols"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, protocol := range protocols {
+		if endpoint, ok := protocol.(map[string]interface{}); ok {
+			delete(endpoint, nodePortKey)
+		}
+	}
+}
+
+// Returns null objects in the config. The service's telemetry is scanned too, except for its resource attributes
+// where null values are meaningful.
 func (c *Config) nullObjects() []string {
 	var nullKeys []string
 	if nulls := hasNullValue(c.Receivers.Object); len(nulls) > 0 {
@@ -407,6 +872,15 @@ func (c *Config) nullObjects() []string {
 			nullKeys = append(nullKeys, addPrefix("connectors.", nulls)...)
 		}
 	}
+	if c.Service.Telemetry != nil {
+		// Null values in the telemetry resource are intentional, they suppress the attributes the collector would
+		// otherwise add automatically, so they are not reported.
+		for _, null := range hasNullValue(c.Service.Telemetry.Object) {
+			if !strings.HasPrefix(null, "resource.") {
+				nullKeys = append(nullKeys, "service.telemetry."+null)
+			}
+		}
+	}
 	// Make the return deterministic. The config uses maps therefore processing order is non-deterministic.
 	sort.Strings(nullKeys)
 	return nullKeys
@@ -425,54 +899,157 @@ const (
 	defaultServiceHost       = "0.0.0.0"
 )
 
+// MetricsEnabled reports whether the collector exposes its own metrics, which it doesn't when their level is none. An
+// invalid level is reported by Validate, the metrics are deemed enabled meanwhile. MetricsEndpoint doesn't check it,
+// its callers exposing the metrics do.
+func (s *Service) MetricsEnabled() bool {
+	level, err := s.MetricsLevel()
+	return err != nil || level != MetricsLevelNone
+}
+
 // MetricsEndpoint attempts gets the host and port number from the host address without doing any validation regarding the
-// address itself.
+// address itself. When no address is set but readers are, the address of the first prometheus pull reader is used, and
+// an error is returned if there's none, as the collector then has no port to scrape its metrics from.
 // It works even before env var expansion happens, when a simple `net.SplitHostPort` would fail because of the extra colon
 // from the env var, i.e. the address looks like "${env:POD_IP}:4317", "${env:POD_IP}", or "${POD_IP}".
 // In cases which the port itself is a variable, i.e. "${env:POD_IP}:${env:PORT}", this returns an error. This happens
 // because the port is used to generate Service objects and mappings.
 func (s *Service) MetricsEndpoint(logger logr.Logger) (string, int32, error) {
-	telemetry := s.GetTelemetry()
-	if telemetry == nil || telemetry.Metrics.Address == "" {
+	var address string
+	if telemetry := s.GetTelemetry(); telemetry != nil {
+		address = telemetry.Metrics.Address
+	}
+	if address == "" && s.hasMetricsReaders() {
+		readerAddress, ok := s.prometheusReaderAddress()
+		if !ok {
+			errMsg := "couldn't determine metrics port from configuration: no prometheus pull reader in service.telemetry.metrics.readers"
+			logger.Info(errMsg)
+			return "", 0, errors.New(errMsg)
+		}
+		address = readerAddress
+	}
+	if address == "" {
 		return defaultServiceHost, defaultServicePort, nil
 	}
 
-	// The regex below matches on strings that end with a colon followed by the environment variable expansion syntax.
-	// So it should match on strings ending with: ":${env:POD_IP}" or ":${POD_IP}".
-	const portEnvVarRegex = `:\${[env:]?.*}$`
-	isPortEnvVar := regexp.MustCompile(portEnvVarRegex).MatchString(telemetry.Metrics.Address)
-	if isPortEnvVar {
-		errMsg := fmt.Sprintf("couldn't determine metrics port from configuration: %s",
-			telemetry.Metrics.Address)
+	host, port, portIsEnv, err := components.SplitEndpoint(address)
+	if portIsEnv {
+		errMsg := fmt.Sprintf("couldn't determine metrics port from configuration: %s", address)
 		logger.Info(errMsg)
 		return "", 0, errors.New(errMsg)
 	}
-
-	// The regex below matches on strings that end with a colon followed by 1 or more numbers (representing the port).
-	const explicitPortRegex = `:(\d+$)`
-	explicitPortMatches := regexp.MustCompile(explicitPortRegex).FindStringSubmatch(telemetry.Metrics.Address)
-	if len(explicitPortMatches) <= 1 {
-		return telemetry.Metrics.Address, defaultServicePort, nil
-	}
-
-	port, err := strconv.ParseInt(explicitPortMatches[1], 10, 32)
 	if err != nil {
-		errMsg := fmt.Sprintf("couldn't determine metrics port from configuration: %s",
-			telemetry.Metrics.Address)
+		errMsg := fmt.Sprintf("couldn't determine metrics port from configuration: %s", address)
 		logger.Info(errMsg, "error", err)
 		return "", 0, err
 	}
+	if port == components.UnsetPort {
+		return host, defaultServicePort, nil
+	}
+	return host, port, nil
+}
+
+// MetricsPort returns the port the collector exposes its own metrics on, as returned by MetricsEndpoint.
+func (s *Service) MetricsPort(logger logr.Logger) (int32, error) {
+	_, port, err := s.MetricsEndpoint(logger)
+	return port, err
+}
+
+// MetricsHost returns the host the collector exposes its own metrics on, as returned by MetricsEndpoint.
+func (s *Service) MetricsHost(logger logr.Logger) (string, error) {
+	host, _, err := s.MetricsEndpoint(logger)
+	return host, err
+}
+
+// SetMetricsAddress sets the address the collector exposes its own metrics on to the given host and port. IPv6 hosts
+// are bracketed, whether or not they already are. The other telemetry settings are kept.
+func (s *Service) SetMetricsAddress(host string, port int32) {
+	if unbracketed := strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"); net.ParseIP(unbracketed) != nil && strings.Contains(unbracketed, ":") {
+		host = "[" + unbracketed + "]"
+	}
+	address := fmt.Sprintf("%s:%d", host, port)
+	if s.Telemetry == nil {
+		s.Telemetry = &AnyConfig{}
+	}
+	if s.Telemetry.Object == nil {
+		s.Telemetry.Object = map[string]interface{}{}
+	}
+	metrics, ok := s.Telemetry.Object["metrics"].(map[string]interface{})
+	if !ok {
+		metrics = map[string]interface{}{}
+		s.Telemetry.Object["metrics"] = metrics
+	}
+	metrics["address"] = address
+}
+
+// hasMetricsReaders reports whether the collector's own metrics are exposed through service.telemetry.metrics.readers.
+func (s *Service) hasMetricsReaders() bool {
+	if s.Telemetry == nil {
+		return false
+	}
+	metrics, ok := s.Telemetry.Object["metrics"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = metrics["readers"]
+	return ok
+}
 
-	host, _, _ := strings.Cut(telemetry.Metrics.Address, explicitPortMatches[0])
-	return host, int32(port), nil
+// prometheusReaderAddress returns the address of the first prometheus pull reader of the collector's own metrics, and
+// whether there's one.
+func (s *Service) prometheusReaderAddress() (string, bool) {
+	metrics, ok := s.Telemetry.Object["metrics"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	readers, _ := metrics["readers"].([]interface{})
+	for _, reader := range readers {
+		if address, ok := prometheusReaderAddress(reader); ok {
+			return address, true
+		}
+	}
+	return "", false
+}
+
+// prometheusReaderAddress returns the host:port address of the given reader of the collector's own metrics, and whether
+// it's a prometheus pull reader. An unset host or port is left empty.
+func prometheusReaderAddress(reader interface{}) (string, bool) {
+	prometheus, ok := getPathValue(reader, []pathStep{{key: "pull"}, {key: "exporter"}, {key: "prometheus"}})
+	if !ok {
+		return "", false
+	}
+	prometheusMap, ok := prometheus.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	var host, port string
+	if value, ok := prometheusMap["host"]; ok && value != nil {
+		host = fmt.Sprint(value)
+	}
+	if value, ok := prometheusMap["port"]; ok && value != nil {
+		port = fmt.Sprint(value)
+	}
+	address := net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), port)
+	if port == "" {
+		address = strings.TrimSuffix(address, ":")
+	}
+	return address, true
 }
 
 // ApplyDefaults inserts configuration defaults if it has not been set.
 func (s *Service) ApplyDefaults(logger logr.Logger) error {
+	if !s.MetricsEnabled() {
+		// There's no address to default, the collector doesn't expose its metrics.
+		return nil
+	}
 	telemetryAddr, telemetryPort, err := s.MetricsEndpoint(logger)
 	if err != nil {
 		return err
 	}
+	if s.hasMetricsReaders() {
+		// The readers expose the metrics, the collector rejects an address set along with them.
+		return nil
+	}
 
 	tm := &AnyConfig{
 		Object: map[string]interface{}{
@@ -494,6 +1071,16 @@ func (s *Service) ApplyDefaults(logger logr.Logger) error {
 	return nil
 }
 
+const (
+	MetricsLevelNone     = "none"
+	MetricsLevelBasic    = "basic"
+	MetricsLevelNormal   = "normal"
+	MetricsLevelDetailed = "detailed"
+
+	// defaultMetricsLevel is the level the collector uses when none is configured.
+	defaultMetricsLevel = MetricsLevelNormal
+)
+
 // MetricsConfig comes from the collector.
 type MetricsConfig struct {
 	// Level is the level of telemetry metrics, the possible values are:
@@ -507,6 +1094,27 @@ type MetricsConfig struct {
 	Address string `json:"address,omitempty" yaml:"address,omitempty"`
 }
 
+// ResolvedLevel returns the effective telemetry metrics level. The collector matches levels case-insensitively, so the
+// configured value is normalized, and an absent level resolves to the collector's default.
+func (m MetricsConfig) ResolvedLevel() string {
+	level := strings.ToLower(strings.TrimSpace(m.Level))
+	if level == "" {
+		return defaultMetricsLevel
+	}
+	return level
+}
+
+// ValidateLevel returns an error if the configured level is not one the collector accepts.
+func (m MetricsConfig) ValidateLevel() error {
+	switch level := m.ResolvedLevel(); level {
+	case MetricsLevelNone, MetricsLevelBasic, MetricsLevelNormal, MetricsLevelDetailed:
+		return nil
+	default:
+		return fmt.Errorf("unknown telemetry metrics level %q, must be one of: %s, %s, %s, %s",
+			m.Level, MetricsLevelNone, MetricsLevelBasic, MetricsLevelNormal, MetricsLevelDetailed)
+	}
+}
+
 // Telemetry is an intermediary type that allows for easy access to the collector's telemetry settings.
 type Telemetry struct {
 	Metrics MetricsConfig `json:"metrics,omitempty" yaml:"metrics,omitempty"`
@@ -537,6 +1145,108 @@ func (s *Service) GetTelemetry() *Telemetry {
 	return t
 }
 
+// SetTelemetryResource sets the given attributes in the telemetry resource, keeping the other attributes. A nil value
+// is kept as an explicit null, which suppresses an attribute the collector would otherwise add. The values are stored as
+// plain strings and nulls, so the resource renders like one written by hand, with its keys sorted.
+func (s *Service) SetTelemetryResource(attributes map[string]*string) {
+	if s.Telemetry == nil {
+		s.Telemetry = &AnyConfig{}
+	}
+	if s.Telemetry.Object == nil {
+		s.Telemetry.Object = map[string]interface{}{}
+	}
+	resource, ok := s.Telemetry.Object["resource"].(map[string]interface{})
+	if !ok {
+		resource = map[string]interface{}{}
+		s.Telemetry.Object["resource"] = resource
+	}
+	for key, value := range attributes {
+		if value == nil {
+			resource[key] = nil
+		} else {
+			resource[key] = *value
+		}
+	}
+}
+
+// MergeResource merges the given attributes into the telemetry resource and returns, sorted, the keys that were already
+// set to a different value. A null, which suppresses the attribute, differs from any string. The conflicting keys are
+// only overwritten when overwrite is true, the other attributes are always added. An error is returned, and nothing is
+// merged, if the existing resource isn't a map.
+func (s *Service) MergeResource(attributes map[string]*string, overwrite bool) ([]string, error) {
+	var resource map[string]interface{}
+	if s.Telemetry != nil && s.Telemetry.Object != nil && s.Telemetry.Object["resource"] != nil {
+		var ok bool
+		if resource, ok = s.Telemetry.Object["resource"].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("service.telemetry.resource is a %T, not a map", s.Telemetry.Object["resource"])
+		}
+	}
+	var conflicts []string
+	merged := map[string]*string{}
+	for key, value := range attributes {
+		existing, ok := resource[key]
+		if !ok {
+			merged[key] = value
+			continue
+		}
+		if sameResourceValue(existing, value) {
+			continue
+		}
+		conflicts = append(conflicts, key)
+		if overwrite {
+			merged[key] = value
+		}
+	}
+	if len(merged) > 0 {
+		s.SetTelemetryResource(merged)
+	}
+	slices.Sort(conflicts)
+	return conflicts, nil
+}
+
+// ResolvedResourceAttributes returns the resource attributes the collector reports its telemetry with: the given
+// defaults, such as those the collector adds on its own, overridden by the telemetry resource's attributes, without the
+// attributes the resource suppresses with a null.
+func (s *Service) ResolvedResourceAttributes(defaults map[string]string) map[string]string {
+	resolved := maps.Clone(defaults)
+	if resolved == nil {
+		resolved = map[string]string{}
+	}
+	telemetry := s.GetTelemetry()
+	if telemetry == nil {
+		return resolved
+	}
+	for key, value := range telemetry.Resource {
+		if value == nil {
+			delete(resolved, key)
+		} else {
+			resolved[key] = *value
+		}
+	}
+	return resolved
+}
+
+// sameResourceValue reports whether the existing value of a resource attribute is the given one, nil being a null.
+func sameResourceValue(existing interface{}, value *string) bool {
+	if existing == nil || value == nil {
+		return existing == nil && value == nil
+	}
+	return fmt.Sprint(existing) == *value
+}
+
+// MetricsLevel returns the effective telemetry metrics level, falling back to the collector's default when the
+// telemetry block or the level itself is absent. An error is returned if the configured level is invalid.
+func (s *Service) MetricsLevel() (string, error) {
+	metrics := MetricsConfig{}
+	if telemetry := s.GetTelemetry(); telemetry != nil {
+		metrics = telemetry.Metrics
+	}
+	if err := metrics.ValidateLevel(); err != nil {
+		return "", err
+	}
+	return metrics.ResolvedLevel(), nil
+}
+
 func hasNullValue(cfg map[string]interface{}) []string {
 	var nullKeys []string
 	for k, v := range cfg {
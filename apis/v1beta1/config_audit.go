@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// mutationRecorder records a mutation applied to a config, described by the given key/value pairs.
+type mutationRecorder func(mutation string, keysAndValues ...interface{})
+
+// discardMutation is the recorder of the mutations applied outside an AuditedConfig.
+func discardMutation(string, ...interface{}) {}
+
+// AuditedConfig mutates a config, logging a structured line describing each change, such as to keep an audit trail of
+// the changes the operator applies to a user's config. The line holds the name of the mutation, the mutated element
+// and, where relevant, its old and new values. Only the mutators with an audited counterpart are exposed, so that every
+// change made through an AuditedConfig is logged. The audit log isn't part of the config itself, so that the config and
+// its copies stay plain API objects.
+// +kubebuilder:object:generate=false
+type AuditedConfig struct {
+	config *Config
+	logger logr.Logger
+}
+
+// WithAuditLog returns an AuditedConfig mutating the config and logging the mutations to the given logger. The
+// mutations applied to the config directly aren't logged.
+func (c *Config) WithAuditLog(logger logr.Logger) *AuditedConfig {
+	return &AuditedConfig{config: c, logger: logger}
+}
+
+// record logs the given mutation.
+func (a *AuditedConfig) record(mutation string, keysAndValues ...interface{}) {
+	a.logger.Info("config mutated", append([]interface{}{"mutation", mutation}, keysAndValues...)...)
+}
+
+// SetReceiverEndpoint is Config.SetReceiverEndpoint, logging the mutation.
+func (a *AuditedConfig) SetReceiverEndpoint(id, endpoint string) error {
+	return a.config.setReceiverEndpoint(a.record, id, endpoint)
+}
+
+// RemoveComponent is Config.RemoveComponent, logging the mutation.
+func (a *AuditedConfig) RemoveComponent(kind ComponentKind, id string) bool {
+	return a.config.removeComponent(a.record, kind, id)
+}
+
+// SetExporterHeader is Config.SetExporterHeader, logging the mutation without the header's value.
+func (a *AuditedConfig) SetExporterHeader(id, key, value string) error {
+	return a.config.setExporterHeader(a.record, id, key, value)
+}
+
+// RemoveExporterHeader is Config.RemoveExporterHeader, logging the mutation.
+func (a *AuditedConfig) RemoveExporterHeader(id, key string) error {
+	return a.config.removeExporterHeader(a.record, id, key)
+}
+
+// SetProcessors is Config.SetProcessors, logging the mutation.
+func (a *AuditedConfig) SetProcessors(pipeline string, processors []string) error {
+	return a.config.setProcessors(a.record, pipeline, processors)
+}
+
+// EnableDebugExporter is Config.EnableDebugExporter, logging the mutation.
+func (a *AuditedConfig) EnableDebugExporter(verbosity string) error {
+	return a.config.enableDebugExporter(a.record, verbosity)
+}
+
+// RemoveProcessorFromPipelines is Config.RemoveProcessorFromPipelines, logging the mutation.
+func (a *AuditedConfig) RemoveProcessorFromPipelines(id string) int {
+	return a.config.removeProcessorFromPipelines(a.record, id)
+}
+
+// UnionPipeline is Config.UnionPipeline, logging the mutation.
+func (a *AuditedConfig) UnionPipeline(name string, receivers, processors, exporters []string) {
+	a.config.unionPipeline(a.record, name, receivers, processors, exporters)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithAuditLog(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{})
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"jaeger": map[string]interface{}{"endpoint": "0.0.0.0:14250"},
+				"zipkin": nil,
+			},
+		},
+	}
+
+	require.NoError(t, c.SetReceiverEndpoint("jaeger", "0.0.0.0:14251"))
+	assert.Empty(t, lines, "nothing is logged without an audit log")
+
+	audited := c.WithAuditLog(logger)
+	require.NoError(t, audited.SetReceiverEndpoint("jaeger", "0.0.0.0:14252"))
+	require.NoError(t, audited.SetReceiverEndpoint("zipkin", "0.0.0.0:9411"))
+	assert.True(t, audited.RemoveComponent(KindReceiver, "zipkin"))
+	assert.False(t, audited.RemoveComponent(KindReceiver, "zipkin"))
+	assert.Equal(t, []string{
+		`"level"=0 "msg"="config mutated" "mutation"="SetReceiverEndpoint" "receiver"="jaeger" "old"="0.0.0.0:14251" "new"="0.0.0.0:14252"`,
+		`"level"=0 "msg"="config mutated" "mutation"="SetReceiverEndpoint" "receiver"="zipkin" "old"=null "new"="0.0.0.0:9411"`,
+		`"level"=0 "msg"="config mutated" "mutation"="RemoveComponent" "kind"="receiver" "id"="zipkin"`,
+	}, lines)
+	assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:14252"}, c.Receivers.Object["jaeger"])
+
+	lines = nil
+	require.NoError(t, c.SetReceiverEndpoint("jaeger", "0.0.0.0:14253"))
+	require.NoError(t, c.DeepCopy().SetReceiverEndpoint("jaeger", "0.0.0.0:14254"))
+	assert.Empty(t, lines, "the mutations applied to the config directly aren't logged")
+
+	assert.EqualError(t, audited.SetReceiverEndpoint("otlp", "0.0.0.0:4317"), "receiver otlp not found")
+	assert.Empty(t, lines, "failed mutations aren't logged")
+}
@@ -0,0 +1,1023 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// debugComponentTypes holds the component types, by kind, that are only useful while debugging a collector and should
+// not be deployed to production.
+var debugComponentTypes = map[ComponentKind][]string{
+	KindExporter:  {"debug", "logging"},
+	KindExtension: {"pprof", "zpages"},
+}
+
+// debugEndpointExtensionTypes holds the types of the extensions serving endpoints that help debugging a collector.
+var debugEndpointExtensionTypes = []string{"health_check", "pprof", "zpages"}
+
+// debugExtensionParsers holds the parsers resolving the ports of the debug extensions without a registered parser. They
+// aren't registered, so that the ports of these extensions, listening on localhost by default, aren't exposed.
+var debugExtensionParsers = map[string]components.Parser{
+	"pprof":  components.NewSilentSinglePortParserBuilder("pprof", 1777).WithAppProtocol(&components.HttpProtocol).MustBuild(),
+	"zpages": components.NewSilentSinglePortParserBuilder("zpages", 55679).WithAppProtocol(&components.HttpProtocol).MustBuild(),
+}
+
+// scrapeableComponentTypes holds the component types, by kind, serving metrics for Prometheus to scrape. The prometheus
+// receiver isn't one of them, it scrapes other endpoints rather than serving one.
+var scrapeableComponentTypes = map[ComponentKind][]string{
+	KindExporter: {"prometheus"},
+}
+
+const (
+	// defaultScrapePath is the path metrics are served on for Prometheus to scrape.
+	defaultScrapePath = "/metrics"
+	// telemetryScrapeTargetID is the component ID of the scrape target serving the collector's own metrics.
+	telemetryScrapeTargetID = "service.telemetry"
+)
+
+// ScrapeTarget is an endpoint of the collector serving metrics for Prometheus to scrape.
+// +kubebuilder:object:generate=false
+type ScrapeTarget struct {
+	// ComponentID is the ID of the component serving the metrics, or "service.telemetry" for the collector's own
+	// metrics.
+	ComponentID string
+	// Port is the port the metrics are served on.
+	Port int32
+	// Path is the HTTP path the metrics are served on.
+	Path string
+}
+
+// Endpoint is an endpoint served by a component of the collector.
+// +kubebuilder:object:generate=false
+type Endpoint struct {
+	// Kind is the kind of the component serving the endpoint.
+	Kind ComponentKind
+	// ComponentID is the ID of the component serving the endpoint, such as "pprof" or "zpages/2".
+	ComponentID string
+	// Name is the name of the port, as used by the collector's container and service.
+	Name string
+	// Port is the port the endpoint listens on in the collector's container.
+	Port int32
+}
+
+// redactedValue replaces sensitive values in redacted configs.
+const redactedValue = "***"
+
+// sensitiveKeyRegex matches the keys whose values may hold credentials and must be redacted before logging.
+var sensitiveKeyRegex = regexp.MustCompile(`(?i)(password|token|secret|api_key|authorization)`)
+
+// componentSection returns the section of the config holding the definitions of the given kind of components. The
+// result is nil if the optional section isn't set.
+func (c *Config) componentSection(kind ComponentKind) *AnyConfig {
+	switch kind {
+	case KindReceiver:
+		return &c.Receivers
+	case KindExporter:
+		return &c.Exporters
+	case KindProcessor:
+		return c.Processors
+	case KindExtension:
+		return c.Extensions
+	case KindConnector:
+		return c.Connectors
+	}
+	return nil
+}
+
+// KindOf returns the kind of the component defined with the given ID. The sections are searched in the order
+// receivers, exporters, processors, connectors and extensions, and the first one defining the ID wins, as the same ID
+// may be used for several kinds of components, e.g. "otlp". Note that a connector is referenced by the pipelines both
+// as a receiver and as an exporter, but it's defined, and reported, as a connector. The result is false if the ID
+// isn't defined anywhere.
+func (c *Config) KindOf(id string) (ComponentKind, bool) {
+	for _, kind := range []ComponentKind{KindReceiver, KindExporter, KindProcessor, KindConnector, KindExtension} {
+		if section := c.componentSection(kind); section != nil {
+			if _, ok := section.Object[id]; ok {
+				return kind, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ComponentsOfKind returns every component of the given kind defined in the config, whether enabled in a pipeline or
+// not, along with its configuration. A component without configuration, or with one that isn't a map, has a nil
+// configuration. The result is never nil.
+func (c *Config) ComponentsOfKind(kind ComponentKind) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	section := c.componentSection(kind)
+	if section == nil {
+		return result
+	}
+	for id, cfg := range section.Object {
+		cfgMap, _ := cfg.(map[string]interface{})
+		result[id] = cfgMap
+	}
+	return result
+}
+
+// ComponentsOfType returns the components of the given kind whose type is the given base type, whatever their name,
+// such as "otlp" and "otlp/2" for "otlp", along with their configuration. Like ComponentsOfKind, every defined component
+// is returned, whether enabled or not, and the configurations are those of the config, not copies. The result is never
+// nil.
+func (c *Config) ComponentsOfType(kind ComponentKind, baseType string) map[string]map[string]interface{} {
+	ofType := c.ComponentsOfKind(kind)
+	maps.DeleteFunc(ofType, func(id string, _ map[string]interface{}) bool {
+		return components.ComponentType(id) != baseType
+	})
+	return ofType
+}
+
+// ReceiversOfType returns the receivers whose type is the given base type, as returned by ComponentsOfType.
+func (c *Config) ReceiversOfType(baseType string) map[string]map[string]interface{} {
+	return c.ComponentsOfType(KindReceiver, baseType)
+}
+
+// ComponentUsage returns, by component, the number of pipelines referencing the component, whether as a receiver, a
+// processor or an exporter, a pipeline referencing it several times counting once. The extensions enabled in the service
+// count once. The components are keyed by their kind's section and their ID, such as "receivers.otlp", so that
+// components of different kinds sharing an ID are counted apart; a connector is referenced as a receiver and as an
+// exporter, but counted under "connectors". Every defined component is present, zero meaning it's unused, as are the
+// referenced components that aren't defined.
+func (c *Config) ComponentUsage() map[string]int {
+	usage := map[string]int{}
+	for _, kind := range AllComponentKinds() {
+		for id := range c.ComponentsOfKind(kind) {
+			usage[componentUsageKey(kind, id)] = 0
+		}
+	}
+	connectors := c.ComponentsOfKind(KindConnector)
+	for _, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		referenced := map[string]struct{}{}
+		for _, kind := range []ComponentKind{KindReceiver, KindProcessor, KindExporter} {
+			for _, id := range pipeline.componentIDs(kind) {
+				referencedKind := kind
+				if _, ok := connectors[id]; ok && kind != KindProcessor {
+					referencedKind = KindConnector
+				}
+				referenced[componentUsageKey(referencedKind, id)] = struct{}{}
+			}
+		}
+		for key := range referenced {
+			usage[key]++
+		}
+	}
+	for _, id := range c.OrderedServiceExtensions() {
+		usage[componentUsageKey(KindExtension, id)]++
+	}
+	return usage
+}
+
+// componentUsageKey returns the key of the given component in the map returned by ComponentUsage.
+func componentUsageKey(kind ComponentKind, id string) string {
+	return kind.String() + "s." + id
+}
+
+// SuggestComponentName returns an ID for a new component of the given kind and type that no component of the kind
+// uses yet: the base type itself if it's free, otherwise the base type followed by the first free number from 2, such as
+// "otlp/2" when "otlp" is taken.
+func (c *Config) SuggestComponentName(kind ComponentKind, baseType string) string {
+	defined := c.ComponentsOfKind(kind)
+	if _, ok := defined[baseType]; !ok {
+		return baseType
+	}
+	for n := 2; ; n++ {
+		id := fmt.Sprintf("%s/%d", baseType, n)
+		if _, ok := defined[id]; !ok {
+			return id
+		}
+	}
+}
+
+// ListeningEndpoints returns the endpoints the enabled components listen on. The receivers come first, then the
+// exporters, such as the prometheus exporter, the connectors and the extensions. Within a kind, components are sorted by
+// ID, except for the extensions which are in the order they're declared.
+func (c *Config) ListeningEndpoints(logger logr.Logger) ([]Endpoint, error) {
+	enabledComponents := c.GetEnabledComponents()
+	var endpoints []Endpoint
+	for _, kind := range []ComponentKind{KindReceiver, KindExporter, KindConnector, KindExtension} {
+		ids := slices.Sorted(maps.Keys(enabledComponents[kind]))
+		if kind == KindExtension {
+			ids = c.OrderedServiceExtensions()
+		}
+		for _, id := range ids {
+			componentEndpoints, err := c.componentEndpoints(logger, kind, id, parserRetrievers.For(kind)(id))
+			if err != nil {
+				return nil, err
+			}
+			endpoints = append(endpoints, componentEndpoints...)
+		}
+	}
+	return endpoints, nil
+}
+
+// componentEndpoints returns the endpoints the given component listens on, with its ports resolved by the given parser.
+func (c *Config) componentEndpoints(logger logr.Logger, kind ComponentKind, id string, parser components.Parser) ([]Endpoint, error) {
+	ports, err := parser.Ports(logger, id, c.componentConfigs(kind)[id])
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []Endpoint
+	for _, port := range ports {
+		endpoint := Endpoint{Kind: kind, ComponentID: id, Name: port.Name, Port: port.Port}
+		if port.TargetPort.IntValue() > 0 {
+			endpoint.Port = port.TargetPort.IntVal
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// DebugEndpoints returns the endpoints of the enabled extensions that help debugging a collector, such as pprof, zpages
+// and health_check, in the order the extensions are declared.
+func (c *Config) DebugEndpoints(logger logr.Logger) ([]Endpoint, error) {
+	var debugEndpoints []Endpoint
+	for _, id := range c.OrderedServiceExtensions() {
+		extensionType := components.ComponentType(id)
+		if !slices.Contains(debugEndpointExtensionTypes, extensionType) {
+			continue
+		}
+		parser, ok := debugExtensionParsers[extensionType]
+		if !ok {
+			parser = parserRetrievers.Extensions(id)
+		}
+		endpoints, err := c.componentEndpoints(logger, KindExtension, id, parser)
+		if err != nil {
+			return nil, err
+		}
+		debugEndpoints = append(debugEndpoints, endpoints...)
+	}
+	return debugEndpoints, nil
+}
+
+// ScrapeTargets returns the endpoints serving metrics for Prometheus to scrape: the collector's own metrics first, then
+// those of the enabled components serving metrics, such as the prometheus exporter, sorted by ID. The collector's own
+// metrics are left out when disabled, or when their port is only known at runtime.
+func (c *Config) ScrapeTargets(logger logr.Logger) ([]ScrapeTarget, error) {
+	var targets []ScrapeTarget
+	if c.Service.MetricsEnabled() {
+		if port, err := c.Service.MetricsPort(logger); err == nil {
+			targets = append(targets, ScrapeTarget{ComponentID: telemetryScrapeTargetID, Port: port, Path: defaultScrapePath})
+		}
+	}
+	endpoints, err := c.ListeningEndpoints(logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range endpoints {
+		if slices.Contains(scrapeableComponentTypes[endpoint.Kind], components.ComponentType(endpoint.ComponentID)) {
+			targets = append(targets, ScrapeTarget{ComponentID: endpoint.ComponentID, Port: endpoint.Port, Path: defaultScrapePath})
+		}
+	}
+	return targets, nil
+}
+
+// ExporterEndpoints returns the outbound endpoints the enabled exporters send data to, by exporter ID, as configured.
+// The endpoint is read from the exporter's endpoint field or, when absent, from the first protocol defining one.
+// Exporters without an endpoint, such as the debug exporter, are left out.
+func (c *Config) ExporterEndpoints(logger logr.Logger) (map[string]string, error) {
+	endpoints := map[string]string{}
+	for id := range c.GetEnabledComponents()[KindExporter] {
+		cfg, ok := c.Exporters.Object[id].(map[string]interface{})
+		if !ok {
+			logger.V(2).Info("exporter has no configuration to read an endpoint from", "exporter", id)
+			continue
+		}
+		endpoint, err := exporterEndpoint(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("exporter %s: %w", id, err)
+		}
+		if endpoint != "" {
+			endpoints[id] = endpoint
+		}
+	}
+	return endpoints, nil
+}
+
+// exporterEndpoint returns the endpoint of the given exporter configuration, which is empty if there is none.
+func exporterEndpoint(cfg map[string]interface{}) (string, error) {
+	if endpoint, ok := cfg["endpoint"]; ok {
+		endpointStr, ok := endpoint.(string)
+		if !ok {
+			return "", fmt.Errorf("endpoint must be a string, got %T", endpoint)
+		}
+		return endpointStr, nil
+	}
+	protocols, ok := cfg["protocols"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	names := make([]string, 0, len(protocols))
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if protocol, ok := protocols[name].(map[string]interface{}); ok {
+			if endpoint, err := exporterEndpoint(protocol); err != nil || endpoint != "" {
+				return endpoint, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// PruneEmptySections removes the optional component sections left without any component, such as after removing the
+// last processor, so they don't render as empty blocks. The required receivers and exporters sections are kept, but
+// are made empty maps rather than nulls.
+func (c *Config) PruneEmptySections() {
+	if c.Receivers.Object == nil {
+		c.Receivers.Object = map[string]interface{}{}
+	}
+	if c.Exporters.Object == nil {
+		c.Exporters.Object = map[string]interface{}{}
+	}
+	for _, section := range []**AnyConfig{&c.Processors, &c.Connectors, &c.Extensions} {
+		if *section != nil && len((*section).Object) == 0 {
+			*section = nil
+		}
+	}
+}
+
+// ComponentYAML returns the configuration of the given component encoded as YAML, with its keys sorted, which is
+// handier than the whole config when investigating a single component. An error is returned if the component isn't
+// defined.
+func (c *Config) ComponentYAML(kind ComponentKind, id string) (string, error) {
+	section := c.componentSection(kind)
+	if section == nil {
+		return "", fmt.Errorf("%s %s not found", kind, id)
+	}
+	cfg, ok := section.Object[id]
+	if !ok {
+		return "", fmt.Errorf("%s %s not found", kind, id)
+	}
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	yamlEncoder.SetIndent(2)
+	if err := yamlEncoder.Encode(cfg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DecodeComponentConfig decodes the configuration of the given component into a T, such as a struct holding the
+// fields of a given type of component, going through JSON so that T's json tags apply. A component without
+// configuration decodes to T's zero value. An error is returned if the component isn't defined or if its configuration
+// doesn't fit T.
+func DecodeComponentConfig[T any](c *Config, kind ComponentKind, id string) (*T, error) {
+	section := c.componentSection(kind)
+	if section == nil {
+		return nil, fmt.Errorf("%s %s not found", kind, id)
+	}
+	cfg, ok := section.Object[id]
+	if !ok {
+		return nil, fmt.Errorf("%s %s not found", kind, id)
+	}
+	decoded := new(T)
+	if cfg == nil {
+		return decoded, nil
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for %s %s: %w", kind, id, err)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return nil, fmt.Errorf("invalid configuration for %s %s: %w", kind, id, err)
+	}
+	return decoded, nil
+}
+
+// PatchComponent applies the given JSON merge patch, as defined by RFC 7386, to the configuration of the given
+// component: the keys set to null are removed, the objects are merged and any other value replaces the existing one.
+// The patch is decoded like the Config in a collector's spec, numbers becoming floats. An error is returned if the
+// component isn't defined or if the patch isn't a JSON object, in which case the config is left untouched.
+func (c *Config) PatchComponent(kind ComponentKind, id string, patch []byte) error {
+	section := c.componentSection(kind)
+	if section == nil {
+		return fmt.Errorf("%s %s not found", kind, id)
+	}
+	cfg, ok := section.Object[id]
+	if !ok {
+		return fmt.Errorf("%s %s not found", kind, id)
+	}
+	var patchObject map[string]interface{}
+	if err := json.Unmarshal(patch, &patchObject); err != nil {
+		return fmt.Errorf("invalid patch for %s %s: %w", kind, id, err)
+	}
+	if patchObject == nil {
+		return fmt.Errorf("invalid patch for %s %s: the patch must be a JSON object", kind, id)
+	}
+	cfgMap, _ := cfg.(map[string]interface{})
+	section.Object[id] = mergePatch(deepCopyMap(cfgMap), patchObject)
+	return nil
+}
+
+// mergePatch applies the given JSON merge patch to the target, which it modifies, and returns the result.
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	if target == nil {
+		target = map[string]interface{}{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchMap, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+		targetMap, _ := target[key].(map[string]interface{})
+		target[key] = mergePatch(targetMap, patchMap)
+	}
+	return target
+}
+
+// RemoveComponent removes the definition of the given component along with every reference to it from the pipelines
+// and, for extensions, from the service's extensions. It reports whether anything was removed.
+func (c *Config) RemoveComponent(kind ComponentKind, id string) bool {
+	return c.removeComponent(discardMutation, kind, id)
+}
+
+// removeComponent is RemoveComponent, recording the mutation with the given recorder.
+func (c *Config) removeComponent(record mutationRecorder, kind ComponentKind, id string) bool {
+	removed := false
+	if section := c.componentSection(kind); section != nil {
+		if _, ok := section.Object[id]; ok {
+			delete(section.Object, id)
+			removed = true
+		}
+	}
+	for _, pipeline := range c.Service.Pipelines {
+		if pipeline != nil && pipeline.removeComponentID(kind, id) {
+			removed = true
+		}
+	}
+	if kind == KindExtension {
+		kept := make([]string, 0, len(c.Service.Extensions))
+		for _, extension := range c.Service.Extensions {
+			if extension != id {
+				kept = append(kept, extension)
+			}
+		}
+		if len(kept) != len(c.Service.Extensions) {
+			c.Service.Extensions = kept
+			removed = true
+		}
+	}
+	if removed {
+		record("RemoveComponent", "kind", kind.String(), "id", id)
+	}
+	return removed
+}
+
+// SetReceiverEndpoint sets the endpoint the given receiver listens on, replacing its endpoint field. The endpoints of
+// the receivers with one endpoint per protocol, such as the OTLP one, are set with ApplySet instead. An error is
+// returned if the receiver isn't defined.
+func (c *Config) SetReceiverEndpoint(id, endpoint string) error {
+	return c.setReceiverEndpoint(discardMutation, id, endpoint)
+}
+
+// setReceiverEndpoint is SetReceiverEndpoint, recording the mutation with the given recorder.
+func (c *Config) setReceiverEndpoint(record mutationRecorder, id, endpoint string) error {
+	cfg, ok := c.Receivers.Object[id]
+	if !ok {
+		return fmt.Errorf("receiver %s not found", id)
+	}
+	var cfgMap map[string]interface{}
+	switch val := cfg.(type) {
+	case nil:
+		cfgMap = map[string]interface{}{}
+	case map[string]interface{}:
+		cfgMap = val
+	default:
+		return fmt.Errorf("receiver %s's configuration is a %T, not a map", id, cfg)
+	}
+	old := cfgMap["endpoint"]
+	cfgMap["endpoint"] = endpoint
+	c.Receivers.Object[id] = cfgMap
+	record("SetReceiverEndpoint", "receiver", id, "old", old, "new", endpoint)
+	return nil
+}
+
+// SetExporterHeader sets the given header, such as an authorization one, in the headers the given exporter sends,
+// creating its headers as needed and replacing the header's existing value. As headers often hold credentials, the
+// audit log doesn't record their values. An error is returned if the exporter isn't defined.
+func (c *Config) SetExporterHeader(id, key, value string) error {
+	return c.setExporterHeader(discardMutation, id, key, value)
+}
+
+// setExporterHeader is SetExporterHeader, recording the mutation with the given recorder.
+func (c *Config) setExporterHeader(record mutationRecorder, id, key, value string) error {
+	cfg, err := c.exporterConfigMap(id, true)
+	if err != nil {
+		return err
+	}
+	headers, ok := cfg["headers"].(map[string]interface{})
+	if !ok {
+		headers = map[string]interface{}{}
+		cfg["headers"] = headers
+	}
+	headers[key] = value
+	record("SetExporterHeader", "exporter", id, "header", key)
+	return nil
+}
+
+// RemoveExporterHeader removes the given header from the headers the given exporter sends, removing the headers
+// altogether once empty. Removing a header that isn't set does nothing. An error is returned if the exporter isn't
+// defined.
+func (c *Config) RemoveExporterHeader(id, key string) error {
+	return c.removeExporterHeader(discardMutation, id, key)
+}
+
+// removeExporterHeader is RemoveExporterHeader, recording the mutation with the given recorder.
+func (c *Config) removeExporterHeader(record mutationRecorder, id, key string) error {
+	cfg, err := c.exporterConfigMap(id, false)
+	if err != nil {
+		return err
+	}
+	headers, ok := cfg["headers"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if _, ok := headers[key]; !ok {
+		return nil
+	}
+	delete(headers, key)
+	if len(headers) == 0 {
+		delete(cfg, "headers")
+	}
+	record("RemoveExporterHeader", "exporter", id, "header", key)
+	return nil
+}
+
+// exporterConfigMap returns the configuration of the given exporter. An empty configuration is replaced by an empty map,
+// so that it can be modified, if create is set, and returned as a nil map otherwise. An error is returned if the
+// exporter isn't defined or if its configuration isn't a map.
+func (c *Config) exporterConfigMap(id string, create bool) (map[string]interface{}, error) {
+	cfg, ok := c.Exporters.Object[id]
+	if !ok {
+		return nil, fmt.Errorf("exporter %s not found", id)
+	}
+	switch val := cfg.(type) {
+	case nil:
+		if !create {
+			return nil, nil
+		}
+		cfgMap := map[string]interface{}{}
+		c.Exporters.Object[id] = cfgMap
+		return cfgMap, nil
+	case map[string]interface{}:
+		return val, nil
+	default:
+		return nil, fmt.Errorf("exporter %s's configuration is a %T, not a map", id, cfg)
+	}
+}
+
+// EnsureExtension makes sure the given extension is defined and enabled in the service, such as an extension the
+// operator relies on. The extension is defined with a copy of the given configuration only if it isn't defined yet, an
+// existing definition is never overwritten. It's appended to the service's extensions only if it isn't already there.
+// It reports whether anything was added.
+func (c *Config) EnsureExtension(id string, defaultCfg map[string]interface{}) bool {
+	added := false
+	if c.Extensions == nil {
+		c.Extensions = &AnyConfig{}
+	}
+	if c.Extensions.Object == nil {
+		c.Extensions.Object = map[string]interface{}{}
+	}
+	if _, ok := c.Extensions.Object[id]; !ok {
+		var cfg interface{}
+		if defaultCfg != nil {
+			cfg = deepCopyMap(defaultCfg)
+		}
+		c.Extensions.Object[id] = cfg
+		added = true
+	}
+	if !slices.Contains(c.Service.Extensions, id) {
+		c.Service.Extensions = append(c.Service.Extensions, id)
+		added = true
+	}
+	return added
+}
+
+// podIPHost is the host receivers bind to so that they only listen on the pod's IP, which the collector's container
+// gets from its POD_IP environment variable.
+const podIPHost = "${env:POD_IP}"
+
+// BindReceiversToPodIP rewrites the host of the receivers' endpoints, and of their protocols' endpoints, to the pod's
+// IP, keeping their port, such as for a sidecar that mustn't listen on every interface. Both the endpoint and the
+// listen_address fields are rewritten. Only the receivers listening on their endpoints, as reported by their parser,
+// are rewritten, as the endpoint of the others, such as the kubeletstats or redis ones, is the target they connect to.
+// Endpoints with a scheme, or without a port, such as Unix sockets, are left alone, as are the exporters and the
+// connectors. Endpoints left to their default aren't set, so ApplyDefaults must be called first for them to be bound.
+func (c *Config) BindReceiversToPodIP() {
+	for id, cfg := range c.ComponentsOfKind(KindReceiver) {
+		if !listensOnEndpoints(id, cfg) {
+			continue
+		}
+		for _, endpointCfg := range endpointConfigs(cfg) {
+			bindEndpointsToPodIP(endpointCfg)
+		}
+	}
+}
+
+// endpointFields holds the fields of a receiver's configuration, or of its protocols' configuration, holding the
+// address it listens on.
+var endpointFields = []string{"endpoint", "listen_address"}
+
+// endpointConfigs returns the given receiver configuration along with the configurations of its protocols, which may
+// set the endpoints it listens on.
+func endpointConfigs(cfg map[string]interface{}) []map[string]interface{} {
+	configs := []map[string]interface{}{cfg}
+	protocols, _ := cfg["protocols"].(map[string]interface{})
+	for _, protocol := range protocols {
+		if protocolCfg, ok := protocol.(map[string]interface{}); ok {
+			configs = append(configs, protocolCfg)
+		}
+	}
+	return configs
+}
+
+// listensOnEndpoints reports whether the receiver with the given ID and configuration listens on its endpoints, which
+// it does if its parser reports ports for them. The ports only known at runtime are replaced by a placeholder for the
+// parser to report them.
+func listensOnEndpoints(id string, cfg map[string]interface{}) bool {
+	probe, _ := deepCopyValue(cfg).(map[string]interface{})
+	for _, endpointCfg := range endpointConfigs(probe) {
+		for _, field := range endpointFields {
+			endpoint, ok := endpointCfg[field].(string)
+			if !ok {
+				continue
+			}
+			if host, _, portIsEnv, err := components.SplitEndpoint(endpoint); err == nil && portIsEnv {
+				endpointCfg[field] = host + ":1"
+			}
+		}
+	}
+	ports, err := parserRetrievers.Receivers(id).Ports(logr.Discard(), id, probe)
+	return err == nil && len(ports) > 0
+}
+
+// bindEndpointsToPodIP rewrites the host of the endpoint and listen_address fields of the given configuration to the
+// pod's IP, keeping their port. Endpoints with a scheme are left alone. The quotes surrounding an endpoint are dropped.
+func bindEndpointsToPodIP(cfg map[string]interface{}) {
+	for _, field := range endpointFields {
+		endpoint, ok := cfg[field].(string)
+		if !ok {
+			continue
+		}
+		endpoint = components.TrimQuotes(endpoint)
+		if strings.Contains(endpoint, "://") {
+			continue
+		}
+		host, port, portIsEnv, err := components.SplitEndpoint(endpoint)
+		if err != nil || (port == components.UnsetPort && !portIsEnv) {
+			continue
+		}
+		// The host is a prefix of the unquoted endpoint, which the port follows.
+		cfg[field] = podIPHost + endpoint[len(host):]
+	}
+}
+
+// tlsFileFields holds the fields of a TLS configuration referencing a file.
+var tlsFileFields = []string{"cert_file", "key_file", "ca_file"}
+
+// TLSFilePaths returns the paths of the files referenced by the TLS configurations of the defined components, both at
+// the top of their configuration and within their protocols, by component, such as to mount the certificates into the
+// collector's container. The components are keyed by their section and ID, such as "receivers.otlp", as a receiver and
+// an exporter may share an ID. The paths of each component are sorted and unique. Paths set from env vars can't be
+// known ahead of time and are skipped. Components referencing no file are left out.
+func (c *Config) TLSFilePaths() map[string][]string {
+	paths := map[string][]string{}
+	for _, kind := range AllComponentKinds() {
+		for id, cfg := range c.ComponentsOfKind(kind) {
+			files := tlsFiles(cfg)
+			if protocols, ok := cfg["protocols"].(map[string]interface{}); ok {
+				for _, protocol := range protocols {
+					if protocolCfg, ok := protocol.(map[string]interface{}); ok {
+						files = append(files, tlsFiles(protocolCfg)...)
+					}
+				}
+			}
+			if len(files) > 0 {
+				slices.Sort(files)
+				paths[kind.String()+"s."+id] = slices.Compact(files)
+			}
+		}
+	}
+	return paths
+}
+
+// tlsFiles returns the paths of the files referenced by the tls block of the given configuration, skipping those set
+// from env vars.
+func tlsFiles(cfg map[string]interface{}) []string {
+	tls, ok := cfg["tls"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var files []string
+	for _, field := range tlsFileFields {
+		if file, ok := tls[field].(string); ok && file != "" && !strings.Contains(file, "${") {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// TrimComponentIDs removes the leading and trailing whitespace, such as left by templating, from the IDs of the defined
+// components and from the references to components in the pipelines and the service's extensions. A reference trimmed
+// into one the list already holds is removed, while the references repeated as they are in the list are left to
+// validation. It returns the number of component definitions renamed. Nothing is trimmed, and 0 is returned, if
+// trimming would make two components of the same kind share an ID, which ValidateTrimmedComponentIDs reports.
+func (c *Config) TrimComponentIDs() int {
+	if c.ValidateTrimmedComponentIDs() != nil {
+		return 0
+	}
+	renamed := 0
+	for _, kind := range AllComponentKinds() {
+		section := c.componentSection(kind)
+		if section == nil {
+			continue
+		}
+		for _, id := range slices.Sorted(maps.Keys(section.Object)) {
+			if trimmedID := strings.TrimSpace(id); trimmedID != id {
+				section.Object[trimmedID] = section.Object[id]
+				delete(section.Object, id)
+				renamed++
+			}
+		}
+	}
+	for _, pipeline := range c.Service.Pipelines {
+		if pipeline != nil {
+			pipeline.Receivers = trimReferences(pipeline.Receivers)
+			pipeline.Processors = trimReferences(pipeline.Processors)
+			pipeline.Exporters = trimReferences(pipeline.Exporters)
+		}
+	}
+	c.Service.Extensions = trimReferences(c.Service.Extensions)
+	return renamed
+}
+
+// trimReferences returns the given component references trimmed, dropping those trimmed into a reference the list
+// already holds. The references that didn't need trimming are all kept.
+func trimReferences(ids []string) []string {
+	if ids == nil {
+		return nil
+	}
+	present := map[string]bool{}
+	for _, id := range ids {
+		if strings.TrimSpace(id) == id {
+			present[id] = true
+		}
+	}
+	trimmed := make([]string, 0, len(ids))
+	for _, id := range ids {
+		trimmedID := strings.TrimSpace(id)
+		if trimmedID != id {
+			if present[trimmedID] {
+				continue
+			}
+			present[trimmedID] = true
+		}
+		trimmed = append(trimmed, trimmedID)
+	}
+	return trimmed
+}
+
+// ValidateTrimmedComponentIDs checks that trimming the components' IDs, as done by TrimComponentIDs, doesn't make two
+// components of the same kind share an ID.
+func (c *Config) ValidateTrimmedComponentIDs() error {
+	var errs []error
+	for _, kind := range AllComponentKinds() {
+		byTrimmedID := map[string][]string{}
+		for _, id := range slices.Sorted(maps.Keys(c.ComponentsOfKind(kind))) {
+			trimmedID := strings.TrimSpace(id)
+			byTrimmedID[trimmedID] = append(byTrimmedID[trimmedID], id)
+		}
+		for _, trimmedID := range slices.Sorted(maps.Keys(byTrimmedID)) {
+			ids := byTrimmedID[trimmedID]
+			if len(ids) < 2 {
+				continue
+			}
+			quoted := make([]string, len(ids))
+			for i, id := range ids {
+				quoted[i] = strconv.Quote(id)
+			}
+			errs = append(errs, fmt.Errorf("%ss %s have the same ID once trimmed", kind, strings.Join(quoted, " and ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StripDebugComponents removes the components that are only useful while debugging, such as the debug exporter or the
+// pprof extension, along with their references. It returns the IDs of the removed components, sorted. Note that a
+// pipeline that only exported to a debug exporter is left without exporters.
+func (c *Config) StripDebugComponents() []string {
+	enabledComponents := c.GetEnabledComponents()
+	var removed []string
+	for kind, types := range debugComponentTypes {
+		ids := map[string]struct{}{}
+		for id := range enabledComponents[kind] {
+			ids[id] = struct{}{}
+		}
+		if section := c.componentSection(kind); section != nil {
+			for id := range section.Object {
+				ids[id] = struct{}{}
+			}
+		}
+		for id := range ids {
+			for _, debugType := range types {
+				if components.ComponentType(id) == debugType && c.RemoveComponent(kind, id) {
+					removed = append(removed, id)
+				}
+			}
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// ComponentsReferencing returns the IDs of the components, by kind, whose configuration holds, at any depth, a string
+// matching the given regular expression, such as a path under a secrets directory. The IDs are sorted and the kinds
+// without matching components are left out. Every defined component is searched, whether enabled or not. An invalid
+// pattern matches no component.
+func (c *Config) ComponentsReferencing(pattern string) map[ComponentKind][]string {
+	referencing := map[ComponentKind][]string{}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return referencing
+	}
+	for _, kind := range AllComponentKinds() {
+		for id, cfg := range c.ComponentsOfKind(kind) {
+			if containsMatch(cfg, re) {
+				referencing[kind] = append(referencing[kind], id)
+			}
+		}
+		sort.Strings(referencing[kind])
+	}
+	return referencing
+}
+
+// ComponentsWithKeyValue returns the IDs of the components, by kind, whose configuration holds the given value at the
+// given dotted path, such as "sending_queue.enabled". The path may index arrays, like ApplySet's. Values are compared
+// in their generic form, so that numbers match whatever their type. The IDs are sorted and the kinds without matching
+// components are left out. A component missing the path doesn't match, and an invalid path matches no component.
+func (c *Config) ComponentsWithKeyValue(path string, value interface{}) map[ComponentKind][]string {
+	matching := map[ComponentKind][]string{}
+	steps, err := parsePath(path)
+	if err != nil {
+		return matching
+	}
+	want, err := toGeneric(value)
+	if err != nil {
+		return matching
+	}
+	for _, kind := range AllComponentKinds() {
+		for id, cfg := range c.ComponentsOfKind(kind) {
+			found, ok := getPathValue(cfg, steps)
+			if !ok {
+				continue
+			}
+			if got, err := toGeneric(found); err == nil && reflect.DeepEqual(got, want) {
+				matching[kind] = append(matching[kind], id)
+			}
+		}
+		sort.Strings(matching[kind])
+	}
+	return matching
+}
+
+// MissingRequiredFields returns the fields, as dotted paths, that the defined components' parsers require but that the
+// components' configurations don't set, by component. The components are keyed by their section and ID, such as
+// "exporters.otlp", as a receiver and an exporter may share an ID. A field set to null is missing. Components missing
+// no field are left out. This is a lighter check than Validate, only looking at the presence of the fields.
+func (c *Config) MissingRequiredFields(logger logr.Logger) map[string][]string {
+	missing := map[string][]string{}
+	for _, kind := range AllComponentKinds() {
+		retriever := parserRetrievers.For(kind)
+		for id, cfg := range c.ComponentsOfKind(kind) {
+			var fields []string
+			for _, field := range retriever(id).RequiredFields() {
+				steps, err := parsePath(field)
+				if err != nil {
+					logger.V(2).Info("ignoring invalid required field", "component", id, "field", field, "error", err)
+					continue
+				}
+				if value, ok := getPathValue(cfg, steps); !ok || value == nil {
+					fields = append(fields, field)
+				}
+			}
+			if len(fields) > 0 {
+				missing[kind.String()+"s."+id] = fields
+			}
+		}
+	}
+	return missing
+}
+
+// containsMatch reports whether the given value is, or holds at any depth, a string matching the regular expression.
+func containsMatch(v interface{}, re *regexp.Regexp) bool {
+	switch val := v.(type) {
+	case string:
+		return re.MatchString(val)
+	case map[string]interface{}:
+		for _, item := range val {
+			if containsMatch(item, re) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			if containsMatch(item, re) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Redacted returns a deep copy of the config where the values that may hold credentials, such as passwords, tokens or
+// headers, are replaced, making it safe to log. The config itself is left untouched.
+func (c *Config) Redacted() *Config {
+	redacted := c.DeepCopy()
+	for _, section := range []*AnyConfig{&redacted.Receivers, &redacted.Exporters, redacted.Processors, redacted.Connectors, redacted.Extensions} {
+		if section != nil && section.Object != nil {
+			section.Object = redactMap(section.Object)
+		}
+	}
+	if redacted.Service.Telemetry != nil && redacted.Service.Telemetry.Object != nil {
+		redacted.Service.Telemetry.Object = deepCopyMap(redacted.Service.Telemetry.Object)
+	}
+	return redacted
+}
+
+// redactMap returns a copy of the given map where the values under sensitive keys, and every header, are redacted.
+func redactMap(m map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k == "headers" || sensitiveKeyRegex.MatchString(k) {
+			redacted[k] = redactAll(v)
+			continue
+		}
+		switch val := v.(type) {
+		case map[string]interface{}:
+			redacted[k] = redactMap(val)
+		case []interface{}:
+			items := make([]interface{}, len(val))
+			for i, item := range val {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					items[i] = redactMap(itemMap)
+				} else {
+					items[i] = deepCopyValue(item)
+				}
+			}
+			redacted[k] = items
+		default:
+			redacted[k] = v
+		}
+	}
+	return redacted
+}
+
+// redactAll returns a copy of the given value where every leaf is redacted, keeping the structure intact.
+func redactAll(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			redacted[k] = redactAll(item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(val))
+		for i, item := range val {
+			redacted[i] = redactAll(item)
+		}
+		return redacted
+	default:
+		return redactedValue
+	}
+}
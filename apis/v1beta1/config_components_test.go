@@ -0,0 +1,990 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	go_yaml "gopkg.in/yaml.v3"
+)
+
+func TestConfig_ComponentsOfKind(t *testing.T) {
+	c := &Config{}
+	assert.Equal(t, map[string]map[string]interface{}{}, c.ComponentsOfKind(KindProcessor))
+
+	c.Processors = &AnyConfig{
+		Object: map[string]interface{}{
+			"batch": nil,
+			"memory_limiter": map[string]interface{}{
+				"limit_mib": 400,
+			},
+		},
+	}
+	assert.Equal(t, map[string]map[string]interface{}{
+		"batch": nil,
+		"memory_limiter": {
+			"limit_mib": 400,
+		},
+	}, c.ComponentsOfKind(KindProcessor))
+	assert.Empty(t, c.ComponentsOfKind(KindReceiver))
+}
+
+func TestConfig_ComponentsOfType(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"otlp/2":   nil,
+				"otlphttp": map[string]interface{}{},
+				"jaeger":   map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{},
+			},
+		},
+	}
+
+	receivers := c.ReceiversOfType("otlp")
+	assert.Equal(t, map[string]map[string]interface{}{
+		"otlp": {
+			"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{},
+			},
+		},
+		"otlp/2": nil,
+	}, receivers)
+
+	// the configurations are those of the config
+	receivers["otlp"]["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["endpoint"] = "${env:POD_IP}:4317"
+	assert.Equal(t, "${env:POD_IP}:4317", c.Receivers.Object["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["endpoint"])
+
+	assert.Equal(t, []string{"otlp"}, slices.Collect(maps.Keys(c.ComponentsOfType(KindExporter, "otlp"))))
+	assert.Equal(t, map[string]map[string]interface{}{}, c.ComponentsOfType(KindProcessor, "batch"))
+}
+
+func TestConfig_ListeningEndpoints(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	endpoints, err := c.ListeningEndpoints(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []Endpoint{
+		{Kind: KindReceiver, ComponentID: "otlp", Name: "otlp-grpc", Port: 4317},
+		{Kind: KindExporter, ComponentID: "prometheus", Name: "prometheus", Port: 8889},
+		{Kind: KindExtension, ComponentID: "health_check", Name: "health-check", Port: 13133},
+	}, endpoints)
+}
+
+func TestConfig_DebugEndpoints(t *testing.T) {
+	c := &Config{
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"zpages": map[string]interface{}{},
+				"pprof/custom": map[string]interface{}{
+					"endpoint": "0.0.0.0:1888",
+				},
+				"basicauth": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"zpages", "basicauth", "pprof/custom"},
+		},
+	}
+
+	endpoints, err := c.DebugEndpoints(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []Endpoint{
+		{Kind: KindExtension, ComponentID: "zpages", Name: "zpages", Port: 55679},
+		{Kind: KindExtension, ComponentID: "pprof/custom", Name: "pprof-custom", Port: 1888},
+	}, endpoints)
+
+	// the debug endpoints aren't exposed
+	ports, err := c.GetExtensionPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Empty(t, ports)
+}
+
+func TestConfig_ScrapeTargets(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	targets, err := c.ScrapeTargets(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []ScrapeTarget{
+		{ComponentID: "service.telemetry", Port: 8888, Path: "/metrics"},
+		{ComponentID: "prometheus", Port: 8889, Path: "/metrics"},
+	}, targets)
+
+	c.Service.SetMetricsAddress("0.0.0.0", 9090)
+	targets, err = c.ScrapeTargets(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, ScrapeTarget{ComponentID: "service.telemetry", Port: 9090, Path: "/metrics"}, targets[0])
+
+	c.Service.Telemetry.Object["metrics"].(map[string]interface{})["address"] = "0.0.0.0:${env:METRICS_PORT}"
+	targets, err = c.ScrapeTargets(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []ScrapeTarget{
+		{ComponentID: "prometheus", Port: 8889, Path: "/metrics"},
+	}, targets, "the telemetry endpoint is left out when its port is only known at runtime")
+}
+
+func TestConfig_ExporterEndpoints(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "https://backend:4317",
+				},
+				"kafka": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"sasl": map[string]interface{}{
+							"mechanism": "PLAIN",
+						},
+						"tcp": map[string]interface{}{
+							"endpoint": "kafka:9092",
+						},
+					},
+				},
+				"debug": nil,
+				"otlphttp/unused": map[string]interface{}{
+					"endpoint": "https://unused:4318",
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"otlp", "kafka", "debug"},
+				},
+			},
+		},
+	}
+
+	endpoints, err := c.ExporterEndpoints(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"otlp":  "https://backend:4317",
+		"kafka": "kafka:9092",
+	}, endpoints)
+
+	c.Exporters.Object["otlp"] = map[string]interface{}{"endpoint": 4317}
+	_, err = c.ExporterEndpoints(logr.Discard())
+	assert.EqualError(t, err, "exporter otlp: endpoint must be a string, got int")
+}
+
+func TestConfig_PruneEmptySections(t *testing.T) {
+	c := &Config{
+		Processors: &AnyConfig{Object: map[string]interface{}{}},
+		Connectors: &AnyConfig{},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"health_check"},
+		},
+	}
+
+	c.PruneEmptySections()
+	assert.Nil(t, c.Processors)
+	assert.Nil(t, c.Connectors)
+	assert.NotNil(t, c.Extensions)
+
+	yamlCfg, err := c.Yaml()
+	require.NoError(t, err)
+	assert.NotContains(t, yamlCfg, "processors:")
+	assert.NotContains(t, yamlCfg, "connectors:")
+	assert.NotContains(t, yamlCfg, "null")
+	assert.Contains(t, yamlCfg, "receivers: {}")
+	assert.Contains(t, yamlCfg, "exporters: {}")
+}
+
+func TestConfig_ComponentYAML(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"http": map[string]interface{}{
+							"endpoint": "0.0.0.0:4318",
+						},
+						"grpc": map[string]interface{}{
+							"endpoint": "0.0.0.0:4317",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	otlpYaml, err := c.ComponentYAML(KindReceiver, "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, `protocols:
+  grpc:
+    endpoint: 0.0.0.0:4317
+  http:
+    endpoint: 0.0.0.0:4318
+`, otlpYaml)
+
+	_, err = c.ComponentYAML(KindReceiver, "jaeger")
+	assert.EqualError(t, err, "receiver jaeger not found")
+	_, err = c.ComponentYAML(KindProcessor, "batch")
+	assert.EqualError(t, err, "processor batch not found")
+}
+
+func TestConfig_KindOf(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-connectors.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	kind, ok := c.KindOf("foo")
+	assert.True(t, ok)
+	assert.Equal(t, KindReceiver, kind)
+
+	kind, ok = c.KindOf("count")
+	assert.True(t, ok)
+	assert.Equal(t, KindConnector, kind)
+
+	_, ok = c.KindOf("unknown")
+	assert.False(t, ok)
+}
+
+func TestConfig_PatchComponent(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp": map[string]interface{}{
+						"endpoint": "backend:4317",
+						"tls": map[string]interface{}{
+							"insecure": true,
+							"ca_file":  "/etc/certs/ca.crt",
+						},
+						"compression": "gzip",
+					},
+					"debug": nil,
+				},
+			},
+		}
+	}
+
+	c := newConfig()
+	require.NoError(t, c.PatchComponent(KindExporter, "otlp", []byte(`{
+		"endpoint": "backend.observability:4317",
+		"tls": {"insecure": null, "cert_file": "/etc/certs/tls.crt"},
+		"compression": null,
+		"sending_queue": {"queue_size": 500}
+	}`)))
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "backend.observability:4317",
+		"tls": map[string]interface{}{
+			"ca_file":   "/etc/certs/ca.crt",
+			"cert_file": "/etc/certs/tls.crt",
+		},
+		"sending_queue": map[string]interface{}{
+			"queue_size": float64(500),
+		},
+	}, c.Exporters.Object["otlp"])
+
+	require.NoError(t, c.PatchComponent(KindExporter, "debug", []byte(`{"verbosity": "detailed"}`)))
+	assert.Equal(t, map[string]interface{}{"verbosity": "detailed"}, c.Exporters.Object["debug"])
+
+	for _, tt := range []struct {
+		desc        string
+		kind        ComponentKind
+		id          string
+		patch       string
+		expectedErr string
+	}{
+		{
+			desc:        "undefined component",
+			kind:        KindExporter,
+			id:          "otlphttp",
+			patch:       `{}`,
+			expectedErr: "exporter otlphttp not found",
+		},
+		{
+			desc:        "undefined section",
+			kind:        KindProcessor,
+			id:          "batch",
+			patch:       `{}`,
+			expectedErr: "processor batch not found",
+		},
+		{
+			desc:        "invalid JSON",
+			kind:        KindExporter,
+			id:          "otlp",
+			patch:       `{"endpoint": `,
+			expectedErr: "invalid patch for exporter otlp: unexpected end of JSON input",
+		},
+		{
+			desc:        "not an object",
+			kind:        KindExporter,
+			id:          "otlp",
+			patch:       `null`,
+			expectedErr: "invalid patch for exporter otlp: the patch must be a JSON object",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newConfig()
+			assert.EqualError(t, c.PatchComponent(tt.kind, tt.id, []byte(tt.patch)), tt.expectedErr)
+			assert.Equal(t, newConfig(), c)
+		})
+	}
+}
+
+func TestConfig_RemoveComponent(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	assert.True(t, c.RemoveComponent(KindProcessor, "batch"))
+	assert.NotContains(t, c.Processors.Object, "batch")
+	assert.Empty(t, c.Service.Pipelines["traces"].Processors)
+	assert.Empty(t, c.Service.Pipelines["metrics"].Processors)
+
+	assert.False(t, c.RemoveComponent(KindProcessor, "batch"))
+}
+
+func TestConfig_EnsureExtension(t *testing.T) {
+	t.Run("absent", func(t *testing.T) {
+		c := &Config{}
+		defaultCfg := map[string]interface{}{"endpoint": "0.0.0.0:13133"}
+		assert.True(t, c.EnsureExtension("health_check", defaultCfg))
+		assert.Equal(t, map[string]interface{}{"health_check": map[string]interface{}{"endpoint": "0.0.0.0:13133"}}, c.Extensions.Object)
+		assert.Equal(t, []string{"health_check"}, c.Service.Extensions)
+
+		// the definition must not share the given configuration
+		defaultCfg["endpoint"] = "0.0.0.0:8080"
+		assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:13133"}, c.Extensions.Object["health_check"])
+
+		assert.False(t, c.EnsureExtension("health_check", defaultCfg))
+		assert.Equal(t, []string{"health_check"}, c.Service.Extensions)
+	})
+
+	t.Run("already present", func(t *testing.T) {
+		c := &Config{
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"health_check": map[string]interface{}{"endpoint": "0.0.0.0:8080"},
+					"pprof":        nil,
+				},
+			},
+			Service: Service{
+				Extensions: []string{"pprof", "health_check"},
+			},
+		}
+		assert.False(t, c.EnsureExtension("health_check", map[string]interface{}{"endpoint": "0.0.0.0:13133"}))
+		assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:8080"}, c.Extensions.Object["health_check"])
+		assert.Equal(t, []string{"pprof", "health_check"}, c.Service.Extensions)
+	})
+
+	t.Run("defined but not enabled", func(t *testing.T) {
+		c := &Config{
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"health_check": map[string]interface{}{"endpoint": "0.0.0.0:8080"},
+				},
+			},
+		}
+		assert.True(t, c.EnsureExtension("health_check", map[string]interface{}{"endpoint": "0.0.0.0:13133"}))
+		assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:8080"}, c.Extensions.Object["health_check"])
+		assert.Equal(t, []string{"health_check"}, c.Service.Extensions)
+	})
+}
+
+func TestConfig_TrimComponentIDs(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp ": map[string]interface{}{
+					"protocols": map[string]interface{}{"grpc": nil},
+				},
+				"jaeger": nil,
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"\tdebug": nil,
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check ": nil,
+			},
+		},
+		Service: Service{
+			Extensions: []string{"health_check "},
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp ", "jaeger", "otlp", "jaeger"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, c.ValidateTrimmedComponentIDs())
+	assert.Equal(t, 3, c.TrimComponentIDs())
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"protocols": map[string]interface{}{"grpc": nil},
+		},
+		"jaeger": nil,
+	}, c.Receivers.Object)
+	assert.Equal(t, map[string]interface{}{"debug": nil}, c.Exporters.Object)
+	assert.Equal(t, []string{"health_check"}, c.Service.Extensions)
+	// the references merged by the trimming are kept once, the ones repeated as they are are left to validation
+	assert.Equal(t, []string{"jaeger", "otlp", "jaeger"}, c.Service.Pipelines["traces"].Receivers)
+
+	assert.Zero(t, c.TrimComponentIDs())
+
+	t.Run("collision", func(t *testing.T) {
+		c := &Config{
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp":   nil,
+					"otlp ":  map[string]interface{}{},
+					"jaeger": nil,
+				},
+			},
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"debug ": nil,
+				},
+			},
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers: []string{"otlp ", "otlp", " jaeger"},
+						Exporters: []string{"debug "},
+					},
+				},
+			},
+		}
+		assert.EqualError(t, c.ValidateTrimmedComponentIDs(), `receivers "otlp" and "otlp " have the same ID once trimmed`)
+		assert.Zero(t, c.TrimComponentIDs())
+		assert.Contains(t, c.Receivers.Object, "otlp ")
+		assert.Contains(t, c.Exporters.Object, "debug ")
+		assert.Equal(t, []string{"otlp ", "otlp", " jaeger"}, c.Service.Pipelines["traces"].Receivers)
+		assert.Equal(t, []string{"debug "}, c.Service.Pipelines["traces"].Exporters)
+	})
+}
+
+func TestConfig_StripDebugComponents(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	removed := c.StripDebugComponents()
+	assert.Equal(t, []string{"debug", "pprof", "zpages"}, removed)
+
+	assert.NotContains(t, c.Exporters.Object, "debug")
+	assert.NotContains(t, c.Extensions.Object, "pprof")
+	assert.NotContains(t, c.Extensions.Object, "zpages")
+	assert.Contains(t, c.Extensions.Object, "health_check")
+	assert.Equal(t, []string{"health_check"}, c.Service.Extensions)
+	assert.Equal(t, []string{"zipkin", "otlp"}, c.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"prometheus"}, c.Service.Pipelines["metrics"].Exporters)
+
+	assert.Empty(t, c.StripDebugComponents())
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "https://backend:4317",
+					"headers": map[string]interface{}{
+						"authorization": "Bearer abc",
+						"x-tenant":      "tenant-a",
+					},
+				},
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"basicauth/client": map[string]interface{}{
+					"client_auth": map[string]interface{}{
+						"username": "admin",
+						"password": "hunter2",
+					},
+				},
+				"oauth2client": map[string]interface{}{
+					"client_id":     "agent",
+					"client_secret": "s3cr3t",
+				},
+			},
+		},
+	}
+
+	redacted := c.Redacted()
+
+	otlp := redacted.Exporters.Object["otlp"].(map[string]interface{})
+	assert.Equal(t, "https://backend:4317", otlp["endpoint"])
+	assert.Equal(t, map[string]interface{}{"authorization": "***", "x-tenant": "***"}, otlp["headers"])
+	basicAuth := redacted.Extensions.Object["basicauth/client"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"username": "admin", "password": "***"}, basicAuth["client_auth"])
+	oauth2 := redacted.Extensions.Object["oauth2client"].(map[string]interface{})
+	assert.Equal(t, "agent", oauth2["client_id"])
+	assert.Equal(t, "***", oauth2["client_secret"])
+
+	// the original config must be left untouched
+	originalOtlp := c.Exporters.Object["otlp"].(map[string]interface{})
+	assert.Equal(t, "Bearer abc", originalOtlp["headers"].(map[string]interface{})["authorization"])
+	assert.Equal(t, "s3cr3t", c.Extensions.Object["oauth2client"].(map[string]interface{})["client_secret"])
+}
+
+func TestConfig_ComponentsReferencing(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{
+							"tls": map[string]interface{}{
+								"cert_file": "/etc/otel/secrets/tls.crt",
+								"key_file":  "/etc/otel/secrets/tls.key",
+							},
+						},
+					},
+				},
+				"jaeger": map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlphttp": map[string]interface{}{
+					"endpoint": "https://backend:4318",
+					"headers": map[string]interface{}{
+						"authorization": "${env:TOKEN}",
+					},
+				},
+				"otlp/2": map[string]interface{}{
+					"tls": map[string]interface{}{
+						"ca_file": "/etc/otel/secrets/ca.crt",
+					},
+				},
+				"otlp": map[string]interface{}{
+					"tls": map[string]interface{}{
+						"ca_file": "/etc/otel/certs/ca.crt",
+					},
+				},
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"bearertokenauth": map[string]interface{}{
+					"filename": "/etc/otel/secrets/token",
+				},
+				"file_storage": map[string]interface{}{
+					"directories": []interface{}{"/var/lib/otel", "/etc/otel/secrets/storage"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[ComponentKind][]string{
+		KindReceiver:  {"otlp"},
+		KindExporter:  {"otlp/2"},
+		KindExtension: {"bearertokenauth", "file_storage"},
+	}, c.ComponentsReferencing("^/etc/otel/secrets/"))
+	assert.Equal(t, map[ComponentKind][]string{KindExporter: {"otlphttp"}}, c.ComponentsReferencing(`\$\{env:`))
+	assert.Empty(t, c.ComponentsReferencing("("), "an invalid pattern matches no component")
+}
+
+func TestConfig_ComponentsWithKeyValue(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"sending_queue": map[string]interface{}{
+						"enabled":    true,
+						"queue_size": 1000,
+					},
+				},
+				"otlp/2": map[string]interface{}{
+					"sending_queue": map[string]interface{}{
+						"enabled":    false,
+						"queue_size": float64(1000),
+					},
+				},
+				"otlphttp": map[string]interface{}{
+					"sending_queue": map[string]interface{}{
+						"enabled": true,
+					},
+				},
+				"debug": nil,
+				"prometheus": map[string]interface{}{
+					"sending_queue": true,
+				},
+			},
+		},
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"metrics": map[string]interface{}{
+						"include": map[string]interface{}{
+							"metric_names": []interface{}{"a", "b"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[ComponentKind][]string{
+		KindExporter: {"otlp", "otlphttp"},
+	}, c.ComponentsWithKeyValue("sending_queue.enabled", true))
+	assert.Equal(t, map[ComponentKind][]string{
+		KindExporter: {"otlp", "otlp/2"},
+	}, c.ComponentsWithKeyValue("sending_queue.queue_size", 1000))
+	assert.Equal(t, map[ComponentKind][]string{
+		KindProcessor: {"filter"},
+	}, c.ComponentsWithKeyValue("metrics.include.metric_names[1]", "b"))
+	assert.Empty(t, c.ComponentsWithKeyValue("retry_on_failure.enabled", true))
+	assert.Empty(t, c.ComponentsWithKeyValue("sending_queue..enabled", true))
+}
+
+func TestConfig_MissingRequiredFields(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{"grpc": nil},
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"tls": map[string]interface{}{"insecure": true},
+				},
+				"otlp/null": map[string]interface{}{
+					"endpoint": nil,
+				},
+				"otlp/set": map[string]interface{}{
+					"endpoint": "backend:4317",
+				},
+				"debug": nil,
+			},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"exporters.otlp":      {"endpoint"},
+		"exporters.otlp/null": {"endpoint"},
+	}, c.MissingRequiredFields(logr.Discard()))
+}
+
+func TestDecodeComponentConfig(t *testing.T) {
+	type tlsConfig struct {
+		Insecure bool   `json:"insecure"`
+		CAFile   string `json:"ca_file"`
+	}
+	type exporterConfig struct {
+		Endpoint string     `json:"endpoint"`
+		TLS      *tlsConfig `json:"tls"`
+	}
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "backend:4317",
+					"tls": map[string]interface{}{
+						"insecure": true,
+					},
+				},
+				"otlp/invalid": map[string]interface{}{
+					"endpoint": 4317,
+				},
+				"debug": nil,
+			},
+		},
+	}
+
+	decoded, err := DecodeComponentConfig[exporterConfig](c, KindExporter, "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, &exporterConfig{
+		Endpoint: "backend:4317",
+		TLS:      &tlsConfig{Insecure: true},
+	}, decoded)
+
+	decoded, err = DecodeComponentConfig[exporterConfig](c, KindExporter, "debug")
+	require.NoError(t, err)
+	assert.Equal(t, &exporterConfig{}, decoded)
+
+	_, err = DecodeComponentConfig[exporterConfig](c, KindExporter, "otlp/invalid")
+	assert.ErrorContains(t, err, "invalid configuration for exporter otlp/invalid: json: cannot unmarshal number")
+
+	_, err = DecodeComponentConfig[exporterConfig](c, KindExporter, "zipkin")
+	assert.EqualError(t, err, "exporter zipkin not found")
+	_, err = DecodeComponentConfig[exporterConfig](c, KindProcessor, "batch")
+	assert.EqualError(t, err, "processor batch not found")
+}
+
+func TestConfig_BindReceiversToPodIP(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+						"http": map[string]interface{}{"endpoint": "localhost:4318"},
+					},
+				},
+				"jaeger": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": nil,
+					},
+				},
+				"statsd":       map[string]interface{}{"endpoint": "0.0.0.0:8125"},
+				"statsd/env":   map[string]interface{}{"endpoint": ":${env:STATSD_PORT}"},
+				"statsd/quote": map[string]interface{}{"endpoint": `"0.0.0.0:8126"`},
+				"statsd/ipv6":  map[string]interface{}{"endpoint": "[::]:8127"},
+				"custom":       map[string]interface{}{"listen_address": "0.0.0.0:${env:CUSTOM_PORT}"},
+				"statsd/http":  map[string]interface{}{"endpoint": "http://0.0.0.0:8125"},
+				"filelog":      map[string]interface{}{"include": []interface{}{"/var/log/*.log"}},
+				"prometheus":   nil,
+				"docker":       map[string]interface{}{"endpoint": "unix:///var/run/docker.sock"},
+				"kubeletstats": map[string]interface{}{"endpoint": "https://${env:K8S_NODE_NAME}:10250"},
+				"redis":        map[string]interface{}{"endpoint": "redis.default.svc:6379"},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{"endpoint": "backend:4317"},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"forward": map[string]interface{}{"endpoint": "0.0.0.0:1234"},
+			},
+		},
+	}
+	c.BindReceiversToPodIP()
+
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{"endpoint": "${env:POD_IP}:4317"},
+				"http": map[string]interface{}{"endpoint": "${env:POD_IP}:4318"},
+			},
+		},
+		"jaeger": map[string]interface{}{
+			"protocols": map[string]interface{}{
+				"grpc": nil,
+			},
+		},
+		"statsd":       map[string]interface{}{"endpoint": "${env:POD_IP}:8125"},
+		"statsd/env":   map[string]interface{}{"endpoint": "${env:POD_IP}:${env:STATSD_PORT}"},
+		"statsd/quote": map[string]interface{}{"endpoint": "${env:POD_IP}:8126"},
+		"statsd/ipv6":  map[string]interface{}{"endpoint": "${env:POD_IP}:8127"},
+		"custom":       map[string]interface{}{"listen_address": "${env:POD_IP}:${env:CUSTOM_PORT}"},
+		"statsd/http":  map[string]interface{}{"endpoint": "http://0.0.0.0:8125"},
+		"filelog":      map[string]interface{}{"include": []interface{}{"/var/log/*.log"}},
+		"prometheus":   nil,
+		"docker":       map[string]interface{}{"endpoint": "unix:///var/run/docker.sock"},
+		"kubeletstats": map[string]interface{}{"endpoint": "https://${env:K8S_NODE_NAME}:10250"},
+		"redis":        map[string]interface{}{"endpoint": "redis.default.svc:6379"},
+	}, c.Receivers.Object)
+	assert.Equal(t, map[string]interface{}{"endpoint": "backend:4317"}, c.Exporters.Object["otlp"])
+	assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:1234"}, c.Connectors.Object["forward"])
+}
+
+func TestConfig_ComponentUsage(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":   nil,
+				"jaeger": nil,
+			},
+		},
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"batch": nil,
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": nil,
+				"otlp":  nil,
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"forward": nil,
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check": nil,
+				"pprof":        nil,
+			},
+		},
+		Service: Service{
+			Extensions: []string{"health_check", "health_check"},
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch", "batch"},
+					Exporters:  []string{"forward"},
+				},
+				"traces/2": {
+					Receivers: []string{"otlp", "forward"},
+					Exporters: []string{"debug", "zipkin"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]int{
+		"receivers.otlp":          2,
+		"receivers.jaeger":        0,
+		"processors.batch":        1,
+		"exporters.debug":         1,
+		"exporters.otlp":          0,
+		"exporters.zipkin":        1,
+		"connectors.forward":      2,
+		"extensions.health_check": 1,
+		"extensions.pprof":        0,
+	}, c.ComponentUsage())
+}
+
+func TestConfig_SetExporterHeader(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "backend:4317",
+					"headers": map[string]interface{}{
+						"authorization": "Bearer old",
+					},
+				},
+				"otlphttp": nil,
+			},
+		},
+	}
+
+	require.NoError(t, c.RemoveExporterHeader("otlphttp", "x-tenant"))
+	assert.Nil(t, c.Exporters.Object["otlphttp"])
+
+	require.NoError(t, c.SetExporterHeader("otlp", "authorization", "Bearer new"))
+	require.NoError(t, c.SetExporterHeader("otlp", "x-tenant", "team-a"))
+	require.NoError(t, c.SetExporterHeader("otlphttp", "x-tenant", "team-b"))
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"endpoint": "backend:4317",
+			"headers": map[string]interface{}{
+				"authorization": "Bearer new",
+				"x-tenant":      "team-a",
+			},
+		},
+		"otlphttp": map[string]interface{}{
+			"headers": map[string]interface{}{
+				"x-tenant": "team-b",
+			},
+		},
+	}, c.Exporters.Object)
+
+	require.NoError(t, c.RemoveExporterHeader("otlp", "authorization"))
+	require.NoError(t, c.RemoveExporterHeader("otlp", "missing"))
+	require.NoError(t, c.RemoveExporterHeader("otlphttp", "x-tenant"))
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"endpoint": "backend:4317",
+			"headers": map[string]interface{}{
+				"x-tenant": "team-a",
+			},
+		},
+		"otlphttp": map[string]interface{}{},
+	}, c.Exporters.Object)
+
+	assert.EqualError(t, c.SetExporterHeader("zipkin", "x-tenant", "team-a"), "exporter zipkin not found")
+	assert.EqualError(t, c.RemoveExporterHeader("zipkin", "x-tenant"), "exporter zipkin not found")
+}
+
+func TestConfig_SuggestComponentName(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":     nil,
+				"jaeger":   nil,
+				"jaeger/2": nil,
+				"jaeger/4": nil,
+			},
+		},
+	}
+
+	assert.Equal(t, "otlp/2", c.SuggestComponentName(KindReceiver, "otlp"))
+	assert.Equal(t, "jaeger/3", c.SuggestComponentName(KindReceiver, "jaeger"))
+	assert.Equal(t, "zipkin", c.SuggestComponentName(KindReceiver, "zipkin"))
+	assert.Equal(t, "otlp", c.SuggestComponentName(KindExporter, "otlp"))
+	assert.Equal(t, "batch", c.SuggestComponentName(KindProcessor, "batch"))
+}
+
+func TestConfig_TLSFilePaths(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{
+						"tls": map[string]interface{}{
+							"cert_file": "/certs/tls.crt",
+							"key_file":  "/certs/tls.key",
+							"ca_file":   "${env:CA_FILE}",
+						},
+					},
+					"http": map[string]interface{}{
+						"tls": map[string]interface{}{
+							"cert_file": "/certs/tls.crt",
+							"key_file":  "/certs/tls.key",
+						},
+					},
+				},
+			},
+			"zipkin": nil,
+		}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint": "backend:4317",
+				"tls":      map[string]interface{}{"ca_file": "/ca/ca.crt"},
+			},
+			"debug": map[string]interface{}{},
+		}},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"receivers.otlp": {"/certs/tls.crt", "/certs/tls.key"},
+		"exporters.otlp": {"/ca/ca.crt"},
+	}, c.TLSFilePaths())
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Sections of a diagnostics report that may fail to be computed.
+const (
+	DiagnosticsSectionPorts              = "ports"
+	DiagnosticsSectionRBACRules          = "rbacRules"
+	DiagnosticsSectionEnvVars            = "envVars"
+	DiagnosticsSectionListeningEndpoints = "listeningEndpoints"
+)
+
+// DiagnosticsReport gathers the analyses of a config in a single result.
+// +kubebuilder:object:generate=false
+type DiagnosticsReport struct {
+	// Ports are the service ports of the enabled components.
+	Ports []corev1.ServicePort `json:"ports,omitempty"`
+	// RBACRules are the RBAC rules the enabled components require.
+	RBACRules []rbacv1.PolicyRule `json:"rbacRules,omitempty"`
+	// EnvVars are the environment variables the enabled components require.
+	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
+	// ListeningEndpoints are the endpoints the enabled components listen on.
+	ListeningEndpoints []Endpoint `json:"listeningEndpoints,omitempty"`
+	// Warnings are the likely mistakes found in the config.
+	Warnings []string `json:"warnings,omitempty"`
+	// ValidationErrors are the problems that would prevent the collector from starting.
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+	// SectionErrors holds, by section, the errors that prevented computing a section of the report.
+	SectionErrors map[string]string `json:"sectionErrors,omitempty"`
+}
+
+// Diagnostics analyzes the config and gathers the results in a report. A section that can't be computed is left empty
+// and its error is recorded in the report, without preventing the other sections from being computed. The returned
+// error joins the errors of every such section, the report is returned regardless.
+func (c *Config) Diagnostics(logger logr.Logger) (*DiagnosticsReport, error) {
+	report := &DiagnosticsReport{
+		Warnings: c.Warnings(),
+	}
+	var errs []error
+	recordErr := func(section string, err error) {
+		if err == nil {
+			return
+		}
+		if report.SectionErrors == nil {
+			report.SectionErrors = map[string]string{}
+		}
+		report.SectionErrors[section] = err.Error()
+		errs = append(errs, fmt.Errorf("%s: %w", section, err))
+	}
+
+	var err error
+	report.Ports, err = c.GetAllPorts(logger)
+	recordErr(DiagnosticsSectionPorts, err)
+	report.RBACRules, err = c.GetAllRbacRules(logger)
+	recordErr(DiagnosticsSectionRBACRules, err)
+	report.EnvVars, err = c.GetAllEnvironmentVariables(logger)
+	recordErr(DiagnosticsSectionEnvVars, err)
+	report.ListeningEndpoints, err = c.ListeningEndpoints(logger)
+	recordErr(DiagnosticsSectionListeningEndpoints, err)
+
+	if err := c.Validate(); err != nil {
+		var joined interface{ Unwrap() []error }
+		if errors.As(err, &joined) {
+			for _, validationErr := range joined.Unwrap() {
+				report.ValidationErrors = append(report.ValidationErrors, validationErr.Error())
+			}
+		} else {
+			report.ValidationErrors = []string{err.Error()}
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
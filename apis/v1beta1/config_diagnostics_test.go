@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+func TestConfig_Diagnostics(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"k8s_events":   map[string]interface{}{},
+				"kubeletstats": map[string]interface{}{},
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"endpoint": "0.0.0.0:8888",
+				},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"forward": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"metrics": {
+					Receivers: []string{"otlp", "k8s_events", "kubeletstats"},
+					Exporters: []string{"prometheus"},
+				},
+			},
+		},
+	}
+
+	report, err := c.Diagnostics(logr.Discard())
+	require.NoError(t, err)
+	assert.NotEmpty(t, report.Ports)
+	assert.NotEmpty(t, report.RBACRules)
+	assert.NotEmpty(t, report.EnvVars)
+	assert.NotEmpty(t, report.ListeningEndpoints)
+	assert.Equal(t, []string{
+		"connectors defined but not used by any pipeline: forward",
+		"pipelines exporting data without a batch processor: metrics",
+	}, report.Warnings)
+	require.Len(t, report.ValidationErrors, 1)
+	assert.Contains(t, report.ValidationErrors[0], "the telemetry metrics port 8888 conflicts with the prometheus port")
+	assert.Empty(t, report.SectionErrors)
+}
+
+func TestConfig_DiagnosticsPartial(t *testing.T) {
+	withExtensionParsers(t, map[string]components.Parser{
+		"diagnosticsbroken": components.NewBuilder[any]().WithName("diagnosticsbroken").
+			WithEnvVarGen(func(logr.Logger, any) ([]corev1.EnvVar, error) {
+				return nil, errors.New("the token can't be resolved")
+			}).MustBuild(),
+	})
+
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"kubeletstats": map[string]interface{}{},
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"diagnosticsbroken": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"diagnosticsbroken"},
+			Pipelines: map[string]*Pipeline{
+				"metrics": {
+					Receivers: []string{"kubeletstats"},
+				},
+			},
+		},
+	}
+
+	report, err := c.Diagnostics(logr.Discard())
+	assert.EqualError(t, err, "envVars: the token can't be resolved")
+	require.NotNil(t, report)
+	assert.Empty(t, report.EnvVars)
+	assert.Equal(t, map[string]string{
+		DiagnosticsSectionEnvVars: "the token can't be resolved",
+	}, report.SectionErrors)
+	assert.NotEmpty(t, report.RBACRules)
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/go-logr/logr"
+)
+
+// Prefixes of the entries returned by Diff.
+const (
+	DiffAdded   = "+ "
+	DiffRemoved = "- "
+	DiffChanged = "~ "
+)
+
+// Clone returns a deep copy of the config. Unlike DeepCopy, which only copies the top level of the components'
+// configurations, nested maps and arrays are copied too, so the clone can be modified without affecting the config.
+func (c *Config) Clone() *Config {
+	clone := c.DeepCopy()
+	for _, section := range []*AnyConfig{&clone.Receivers, &clone.Exporters, clone.Processors, clone.Connectors, clone.Extensions, clone.Service.Telemetry} {
+		if section != nil {
+			section.Object = deepCopyMap(section.Object)
+		}
+	}
+	return clone
+}
+
+// Diff returns the dotted paths of the values that differ between the config and the other one, ordered by path, each
+// prefixed by DiffAdded, DiffRemoved or DiffChanged. A map added or removed as a whole is reported as its leaves,
+// while arrays are compared as a whole.
+func (c *Config) Diff(other *Config) ([]string, error) {
+	before, err := toGeneric(c)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toGeneric(other)
+	if err != nil {
+		return nil, err
+	}
+	var diff []string
+	diffValues("", before, after, &diff)
+	return diff, nil
+}
+
+// ComponentDiff returns the differences, as returned by Diff, between the configurations of the given component in the
+// config and in the other one, such as to show what changed in a single component. A component defined in only one of
+// the configs is reported as added or removed. An error is returned if the component is defined in neither config.
+func (c *Config) ComponentDiff(other *Config, kind ComponentKind, id string) ([]string, error) {
+	before, inBefore := c.componentConfigs(kind)[id]
+	after, inAfter := other.componentConfigs(kind)[id]
+	if !inBefore && !inAfter {
+		return nil, fmt.Errorf("%s %s not found", kind, id)
+	}
+	genericBefore, err := toGeneric(before)
+	if err != nil {
+		return nil, err
+	}
+	genericAfter, err := toGeneric(after)
+	if err != nil {
+		return nil, err
+	}
+	path := joinPath(kind.String()+"s", id)
+	var diff []string
+	switch {
+	case !inBefore:
+		appendLeaves(DiffAdded, path, genericAfter, &diff)
+	case !inAfter:
+		appendLeaves(DiffRemoved, path, genericBefore, &diff)
+	default:
+		diffValues(path, genericBefore, genericAfter, &diff)
+	}
+	return diff, nil
+}
+
+// DefaultsDiff returns the changes ApplyDefaults would make to the config, as returned by Diff, without modifying the
+// config.
+func (c *Config) DefaultsDiff(logger logr.Logger) ([]string, error) {
+	defaulted := c.Clone()
+	if err := defaulted.ApplyDefaults(logger); err != nil {
+		return nil, err
+	}
+	return c.Diff(defaulted)
+}
+
+// Effective returns the config as the collector would run it: a copy with the component IDs trimmed, the defaults
+// applied and the empty optional sections pruned. The config itself isn't modified.
+func (c *Config) Effective(logger logr.Logger) (*Config, error) {
+	effective := c.Clone()
+	if err := effective.ValidateTrimmedComponentIDs(); err != nil {
+		return nil, err
+	}
+	effective.TrimComponentIDs()
+	if err := effective.ApplyDefaults(logger); err != nil {
+		return nil, err
+	}
+	effective.PruneEmptySections()
+	return effective, nil
+}
+
+// EqualIgnoringDefaults reports whether the config and the other one are semantically equal once defaulted, such as a
+// config stored after defaulting and an incoming one that wasn't defaulted yet. Both configs are defaulted on copies
+// rather than having the defaulted keys stripped, so that a value set explicitly in one config and only defaulted in the
+// other is still compared. Neither config is modified.
+func (c *Config) EqualIgnoringDefaults(other *Config, logger logr.Logger) (bool, error) {
+	defaulted := c.Clone()
+	if err := defaulted.ApplyDefaults(logger); err != nil {
+		return false, err
+	}
+	otherDefaulted := other.Clone()
+	if err := otherDefaulted.ApplyDefaults(logger); err != nil {
+		return false, err
+	}
+	diff, err := defaulted.Diff(otherDefaulted)
+	if err != nil {
+		return false, err
+	}
+	return len(diff) == 0, nil
+}
+
+// Fingerprint returns a hash of the config, ignoring the values found at the given dotted paths, as accepted by
+// ApplySet, such as "service.telemetry.metrics.address". It tells whether a change to the config matters, such as to
+// decide whether the collector must be restarted, while the ignored values may change freely. The config is hashed in
+// a canonical form, so that configs differing only in the order of their keys have the same fingerprint. Paths that
+// don't exist in the config are ignored, an error is returned if one is invalid.
+func (c *Config) Fingerprint(ignorePaths ...string) (string, error) {
+	generic, err := toGeneric(c)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range ignorePaths {
+		steps, err := parsePath(path)
+		if err != nil {
+			return "", err
+		}
+		deletePathValue(generic, steps)
+	}
+	// Maps are marshaled with their keys sorted, which makes the JSON form canonical.
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// diffValues appends the differences between the two values, found at the given path, to the diff.
+func diffValues(path string, before, after interface{}, diff *[]string) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	switch {
+	case beforeIsMap && afterIsMap:
+		keys := slices.Collect(maps.Keys(beforeMap))
+		for key := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			beforeValue, inBefore := beforeMap[key]
+			afterValue, inAfter := afterMap[key]
+			switch {
+			case !inBefore:
+				appendLeaves(DiffAdded, joinPath(path, key), afterValue, diff)
+			case !inAfter:
+				appendLeaves(DiffRemoved, joinPath(path, key), beforeValue, diff)
+			default:
+				diffValues(joinPath(path, key), beforeValue, afterValue, diff)
+			}
+		}
+	case before == nil && afterIsMap:
+		appendLeaves(DiffAdded, path, after, diff)
+	case beforeIsMap && after == nil:
+		appendLeaves(DiffRemoved, path, before, diff)
+	case !reflect.DeepEqual(before, after):
+		*diff = append(*diff, DiffChanged+path)
+	}
+}
+
+// appendLeaves appends the paths of the leaves of the given value, found at the given path, to the diff with the given
+// prefix. An empty map is a leaf.
+func appendLeaves(prefix, path string, value interface{}, diff *[]string) {
+	m, ok := value.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		*diff = append(*diff, prefix+path)
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		appendLeaves(prefix, joinPath(path, key), m[key], diff)
+	}
+}
+
+// joinPath appends the key to the dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
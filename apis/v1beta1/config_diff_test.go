@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestConfig_Clone(t *testing.T) {
+	c := newApplySetConfig()
+	c.Service.SetMetricsAddress("0.0.0.0", 8888)
+
+	clone := c.Clone()
+	assert.Equal(t, c, clone)
+
+	clone.Receivers.Object["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["http"] = nil
+	clone.Service.Telemetry.Object["metrics"].(map[string]interface{})["address"] = "0.0.0.0:9090"
+	expected := newApplySetConfig()
+	expected.Service.SetMetricsAddress("0.0.0.0", 8888)
+	assert.Equal(t, expected, c, "modifying the clone must not affect the config")
+}
+
+func TestConfig_Diff(t *testing.T) {
+	c := newApplySetConfig()
+	other := c.Clone()
+	require.NoError(t, other.ApplySet("receivers.otlp.protocols.http.endpoint", "0.0.0.0:4318"))
+	require.NoError(t, other.ApplySet("exporters.debug.verbosity", "detailed"))
+	require.NoError(t, other.ApplySet("service.pipelines.traces.exporters[1]", "otlp"))
+	other.Receivers.Object["jaeger"] = nil
+	delete(other.Receivers.Object["otlp"].(map[string]interface{})["protocols"].(map[string]interface{}), "grpc")
+
+	diff, err := c.Diff(other)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"~ exporters.debug.verbosity",
+		"+ receivers.jaeger",
+		"- receivers.otlp.protocols.grpc",
+		"+ receivers.otlp.protocols.http.endpoint",
+		"~ service.pipelines.traces.exporters",
+	}, diff)
+
+	diff, err = c.Diff(c.Clone())
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestConfig_DefaultsDiff(t *testing.T) {
+	c := newApplySetConfig()
+
+	diff, err := c.DefaultsDiff(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"+ receivers.otlp.protocols.grpc.endpoint",
+		"+ service.telemetry.metrics.address",
+	}, diff)
+	assert.Equal(t, newApplySetConfig(), c, "the config must not change")
+}
+
+func TestConfig_EqualIgnoringDefaults(t *testing.T) {
+	stored := newApplySetConfig()
+	require.NoError(t, stored.ApplyDefaults(logr.Discard()))
+	incoming := newApplySetConfig()
+
+	equal, err := stored.EqualIgnoringDefaults(incoming, logr.Discard())
+	require.NoError(t, err)
+	assert.True(t, equal)
+	assert.Equal(t, newApplySetConfig(), incoming, "the configs must not change")
+
+	incoming.Service.SetMetricsAddress("0.0.0.0", 9090)
+	equal, err = stored.EqualIgnoringDefaults(incoming, logr.Discard())
+	require.NoError(t, err)
+	assert.False(t, equal, "an explicit value differing from the default must be compared")
+
+	incoming = newApplySetConfig()
+	require.NoError(t, incoming.ApplySet("exporters.debug.verbosity", "detailed"))
+	equal, err = stored.EqualIgnoringDefaults(incoming, logr.Discard())
+	require.NoError(t, err)
+	assert.False(t, equal)
+}
+
+func TestConfig_Effective(t *testing.T) {
+	newConfig := func() *Config {
+		c := newApplySetConfig()
+		c.Receivers.Object["otlp "] = c.Receivers.Object["otlp"]
+		delete(c.Receivers.Object, "otlp")
+		c.Processors = &AnyConfig{Object: map[string]interface{}{}}
+		return c
+	}
+	c := newConfig()
+
+	effective, err := c.Effective(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"address": "0.0.0.0:8888",
+		},
+	}, effective.Service.Telemetry.Object)
+	assert.Equal(t, map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{
+				"endpoint": "0.0.0.0:4317",
+			},
+		},
+	}, effective.Receivers.Object["otlp"])
+	assert.Nil(t, effective.Processors)
+	assert.Nil(t, effective.Connectors)
+	assert.Nil(t, effective.Extensions)
+	assert.Equal(t, newConfig(), c, "the config must not change")
+}
+
+func TestConfig_Fingerprint(t *testing.T) {
+	c := newApplySetConfig()
+	c.Service.SetMetricsAddress("0.0.0.0", 8888)
+	other := c.Clone()
+	other.Service.SetMetricsAddress("10.0.0.1", 8888)
+	other.Service.SetTelemetryResource(map[string]*string{"k8s.pod.name": ptr.To("collector-0")})
+
+	ignored := []string{"service.telemetry.metrics.address", "service.telemetry.resource", "service.telemetry.logs"}
+	fingerprint, err := c.Fingerprint(ignored...)
+	require.NoError(t, err)
+	otherFingerprint, err := other.Fingerprint(ignored...)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, otherFingerprint, "configs differing only on ignored paths must have the same fingerprint")
+
+	fingerprint, err = c.Fingerprint()
+	require.NoError(t, err)
+	otherFingerprint, err = other.Fingerprint()
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+
+	require.NoError(t, other.ApplySet("exporters.debug.verbosity", "detailed"))
+	otherFingerprint, err = other.Fingerprint(ignored...)
+	require.NoError(t, err)
+	fingerprint, err = c.Fingerprint(ignored...)
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+
+	_, err = c.Fingerprint("receivers..otlp")
+	assert.Error(t, err)
+}
+
+func TestConfig_ComponentDiff(t *testing.T) {
+	c := newApplySetConfig()
+	other := c.Clone()
+	require.NoError(t, other.ApplySet("exporters.debug.verbosity", "detailed"))
+	require.NoError(t, other.ApplySet("receivers.otlp.protocols.http.endpoint", "0.0.0.0:4318"))
+	require.NoError(t, other.ApplySet("exporters.otlp.endpoint", "backend:4317"))
+
+	diff, err := c.ComponentDiff(other, KindExporter, "debug")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"~ exporters.debug.verbosity"}, diff)
+
+	diff, err = c.ComponentDiff(other, KindExporter, "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"+ exporters.otlp.endpoint"}, diff)
+
+	diff, err = other.ComponentDiff(c, KindExporter, "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"- exporters.otlp.endpoint"}, diff)
+
+	diff, err = c.ComponentDiff(c.Clone(), KindReceiver, "otlp")
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	_, err = c.ComponentDiff(other, KindProcessor, "batch")
+	assert.EqualError(t, err, "processor batch not found")
+}
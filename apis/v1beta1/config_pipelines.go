@@ -0,0 +1,541 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// ComponentRef references a component as used by the pipelines of a config.
+// +kubebuilder:object:generate=false
+type ComponentRef struct {
+	Kind ComponentKind
+	ID   string
+	// Pipelines are the names of the pipelines referencing the component, sorted alphabetically.
+	Pipelines []string
+}
+
+// ResolvedComponent is a component referenced by a pipeline, along with its configuration.
+// +kubebuilder:object:generate=false
+type ResolvedComponent struct {
+	Kind ComponentKind
+	ID   string
+	// Config is the configuration of the component, nil if the component has none.
+	Config map[string]interface{}
+}
+
+// Signal types a pipeline can process, given by the type part of the pipeline's "type[/name]" key.
+const (
+	PipelineTypeTraces   = "traces"
+	PipelineTypeMetrics  = "metrics"
+	PipelineTypeLogs     = "logs"
+	PipelineTypeProfiles = "profiles"
+)
+
+// ValidPipelineType reports whether the given signal type is one a pipeline can process.
+func ValidPipelineType(t string) bool {
+	switch t {
+	case PipelineTypeTraces, PipelineTypeMetrics, PipelineTypeLogs, PipelineTypeProfiles:
+		return true
+	default:
+		return false
+	}
+}
+
+// PipelineType returns the signal type of the pipeline with the given name, e.g. "metrics" for "metrics/2". The result
+// is empty if there's no such pipeline or if its type isn't a valid signal type.
+func (c *Config) PipelineType(name string) string {
+	if _, ok := c.Service.Pipelines[name]; !ok {
+		return ""
+	}
+	if t := components.ComponentType(name); ValidPipelineType(t) {
+		return t
+	}
+	return ""
+}
+
+// componentIDs returns the IDs of the components of the given kind referenced by the pipeline.
+func (p *Pipeline) componentIDs(kind ComponentKind) []string {
+	switch kind {
+	case KindReceiver:
+		return p.Receivers
+	case KindProcessor:
+		return p.Processors
+	case KindExporter:
+		return p.Exporters
+	case KindExtension, KindConnector:
+		return nil
+	}
+	return nil
+}
+
+// removeComponentID removes every reference to the given component ID of the given kind from the pipeline and reports
+// whether any was found.
+func (p *Pipeline) removeComponentID(kind ComponentKind, id string) bool {
+	switch kind {
+	case KindReceiver:
+		return removeID(&p.Receivers, id)
+	case KindProcessor:
+		return removeID(&p.Processors, id)
+	case KindExporter:
+		return removeID(&p.Exporters, id)
+	case KindConnector:
+		// A connector is referenced both as a receiver and as an exporter.
+		removedReceiver := removeID(&p.Receivers, id)
+		removedExporter := removeID(&p.Exporters, id)
+		return removedReceiver || removedExporter
+	case KindExtension:
+	}
+	return false
+}
+
+// removeID removes every occurrence of the given ID from the list and reports whether any was found.
+func removeID(ids *[]string, id string) bool {
+	kept := make([]string, 0, len(*ids))
+	for _, existing := range *ids {
+		if existing != id {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) == len(*ids) {
+		return false
+	}
+	*ids = kept
+	return true
+}
+
+// pipelineNames returns the names of the configured pipelines in a deterministic order.
+func (c *Config) pipelineNames() []string {
+	names := make([]string, 0, len(c.Service.Pipelines))
+	for name, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OrderedComponents returns the components referenced by the pipelines in data-flow order: all receivers first, then
+// processors, then exporters. Within a kind, components are listed in the order they first appear when walking the
+// pipelines alphabetically. Each component is listed once per kind, with every pipeline that references it.
+func (c *Config) OrderedComponents() []ComponentRef {
+	var refs []ComponentRef
+	for _, kind := range []ComponentKind{KindReceiver, KindProcessor, KindExporter} {
+		index := map[string]int{}
+		for _, name := range c.pipelineNames() {
+			for _, id := range c.Service.Pipelines[name].componentIDs(kind) {
+				i, ok := index[id]
+				if !ok {
+					i = len(refs)
+					index[id] = i
+					refs = append(refs, ComponentRef{Kind: kind, ID: id})
+				}
+				if p := refs[i].Pipelines; len(p) == 0 || p[len(p)-1] != name {
+					refs[i].Pipelines = append(refs[i].Pipelines, name)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// SplitByPipelineType splits the config into one config per signal type, by type, such as to run a collector per
+// signal. Each config only holds the pipelines of its type and the receivers, processors, exporters and connectors they
+// reference, while the extensions and the rest of the service are shared. An error is returned if a pipeline's type
+// isn't a valid signal type, or if a connector bridges pipelines of different types, as such a config can't be split.
+func (c *Config) SplitByPipelineType() (map[string]*Config, error) {
+	pipelinesByType := map[string][]string{}
+	connectorTypes := map[string]map[string]struct{}{}
+	connectors := c.ComponentsOfKind(KindConnector)
+	for name, pipeline := range c.Service.Pipelines {
+		pipelineType := components.ComponentType(name)
+		if !ValidPipelineType(pipelineType) {
+			return nil, fmt.Errorf("pipeline %s has an invalid signal type %s", name, pipelineType)
+		}
+		pipelinesByType[pipelineType] = append(pipelinesByType[pipelineType], name)
+		if pipeline == nil {
+			continue
+		}
+		for _, id := range append(slices.Clone(pipeline.Receivers), pipeline.Exporters...) {
+			if _, ok := connectors[id]; !ok {
+				continue
+			}
+			if connectorTypes[id] == nil {
+				connectorTypes[id] = map[string]struct{}{}
+			}
+			connectorTypes[id][pipelineType] = struct{}{}
+		}
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(connectorTypes)) {
+		if types := slices.Sorted(maps.Keys(connectorTypes[id])); len(types) > 1 {
+			errs = append(errs, fmt.Errorf("connector %s bridges %s pipelines and can't be split", id, strings.Join(types, " and ")))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	split := make(map[string]*Config, len(pipelinesByType))
+	for pipelineType, names := range pipelinesByType {
+		typed := c.Clone()
+		allPipelines := typed.Service.Pipelines
+		typed.Service.Pipelines = map[string]*Pipeline{}
+		for _, name := range names {
+			typed.Service.Pipelines[name] = allPipelines[name]
+		}
+		enabledComponents := typed.GetEnabledComponents()
+		for _, kind := range []ComponentKind{KindReceiver, KindExporter, KindProcessor, KindConnector} {
+			if section := typed.componentSection(kind); section != nil {
+				maps.DeleteFunc(section.Object, func(id string, _ interface{}) bool {
+					_, ok := enabledComponents[kind][id]
+					return !ok
+				})
+			}
+		}
+		typed.PruneEmptySections()
+		split[pipelineType] = typed
+	}
+	return split, nil
+}
+
+// ResolvePipeline returns the components of the pipeline with the given name in data-flow order: its receivers, then its
+// processors, then its exporters, each in the order the pipeline lists them. A receiver or exporter that isn't defined
+// as such but as a connector is resolved as a connector. An error is returned if the pipeline doesn't exist or if it
+// references a component that isn't defined.
+func (c *Config) ResolvePipeline(name string) ([]ResolvedComponent, error) {
+	pipeline := c.Service.Pipelines[name]
+	if pipeline == nil {
+		return nil, fmt.Errorf("pipeline %s not found", name)
+	}
+	var resolved []ResolvedComponent
+	for _, kind := range []ComponentKind{KindReceiver, KindProcessor, KindExporter} {
+		for _, id := range pipeline.componentIDs(kind) {
+			definedKind := kind
+			cfg, ok := c.ComponentsOfKind(kind)[id]
+			if !ok && (kind == KindReceiver || kind == KindExporter) {
+				definedKind = KindConnector
+				cfg, ok = c.ComponentsOfKind(KindConnector)[id]
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s %s referenced by pipeline %s is not defined", kind, id, name)
+			}
+			resolved = append(resolved, ResolvedComponent{Kind: definedKind, ID: id, Config: cfg})
+		}
+	}
+	return resolved, nil
+}
+
+// MoveProcessor moves the processor with the given ID from one pipeline to another, inserting it at the given index
+// of the target pipeline's processors. Using the length of the target's processors as the index moves the processor to
+// the end. The pipelines may be the same, in which case the processor is reordered. Nothing is changed if an error is
+// returned.
+func (c *Config) MoveProcessor(id, fromPipeline, toPipeline string, index int) error {
+	from, ok := c.Service.Pipelines[fromPipeline]
+	if !ok || from == nil {
+		return fmt.Errorf("pipeline %s not found", fromPipeline)
+	}
+	to, ok := c.Service.Pipelines[toPipeline]
+	if !ok || to == nil {
+		return fmt.Errorf("pipeline %s not found", toPipeline)
+	}
+	current := slices.Index(from.Processors, id)
+	if current < 0 {
+		return fmt.Errorf("processor %s not found in pipeline %s", id, fromPipeline)
+	}
+	if from != to && slices.Contains(to.Processors, id) {
+		return fmt.Errorf("processor %s is already in pipeline %s", id, toPipeline)
+	}
+
+	remaining := slices.Delete(slices.Clone(from.Processors), current, current+1)
+	target := to.Processors
+	if from == to {
+		target = remaining
+	}
+	if index < 0 || index > len(target) {
+		return fmt.Errorf("index %d out of range for the %d processors of pipeline %s", index, len(target), toPipeline)
+	}
+
+	from.Processors = remaining
+	to.Processors = slices.Insert(slices.Clone(target), index, id)
+	return nil
+}
+
+// SetProcessors replaces the processors of the pipeline with the given name by a copy of the given list, such as to
+// reorder them. An error is returned, and nothing is changed, if the pipeline doesn't exist or if a processor isn't
+// defined. Every undefined processor is reported.
+func (c *Config) SetProcessors(pipeline string, processors []string) error {
+	return c.setProcessors(discardMutation, pipeline, processors)
+}
+
+// setProcessors is SetProcessors, recording the mutation with the given recorder.
+func (c *Config) setProcessors(record mutationRecorder, pipeline string, processors []string) error {
+	p, ok := c.Service.Pipelines[pipeline]
+	if !ok || p == nil {
+		return fmt.Errorf("pipeline %s not found", pipeline)
+	}
+	var errs []error
+	for _, id := range processors {
+		if !c.isDefinedFor(KindProcessor, id) {
+			errs = append(errs, fmt.Errorf("processor %s is not defined", id))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	record("SetProcessors", "pipeline", pipeline, "old", p.Processors, "new", processors)
+	p.Processors = slices.Clone(processors)
+	return nil
+}
+
+// InsertProcessorInAllPipelines adds the given processor at the start, or at the end, of the processors of every
+// pipeline not already using it, such as to enforce a memory_limiter processor. The pipelines already using it are left
+// as they are, so that calling it again changes nothing. An error is returned, and nothing is changed, if the processor
+// isn't defined.
+func (c *Config) InsertProcessorInAllPipelines(id string, atStart bool) error {
+	if !c.isDefinedFor(KindProcessor, id) {
+		return fmt.Errorf("processor %s is not defined", id)
+	}
+	for _, name := range c.pipelineNames() {
+		pipeline := c.Service.Pipelines[name]
+		if slices.Contains(pipeline.Processors, id) {
+			continue
+		}
+		if atStart {
+			pipeline.Processors = slices.Insert(slices.Clone(pipeline.Processors), 0, id)
+		} else {
+			pipeline.Processors = append(slices.Clone(pipeline.Processors), id)
+		}
+	}
+	return nil
+}
+
+// debugExporterVerbosities holds the verbosities the debug exporter accepts.
+var debugExporterVerbosities = []string{"basic", "normal", "detailed"}
+
+// EnableDebugExporter defines a debug exporter with the given verbosity, unless one is already defined, and adds it to
+// the exporters of every pipeline not already using it, so that the data flowing through the collector gets logged.
+// An existing debug exporter keeps its configuration, so that calling it again changes nothing. An error is returned,
+// and nothing is changed, if the verbosity isn't one the debug exporter accepts.
+func (c *Config) EnableDebugExporter(verbosity string) error {
+	return c.enableDebugExporter(discardMutation, verbosity)
+}
+
+// enableDebugExporter is EnableDebugExporter, recording the mutation with the given recorder.
+func (c *Config) enableDebugExporter(record mutationRecorder, verbosity string) error {
+	if !slices.Contains(debugExporterVerbosities, verbosity) {
+		return fmt.Errorf("invalid debug exporter verbosity %q, must be one of: %s", verbosity, strings.Join(debugExporterVerbosities, ", "))
+	}
+	const id = "debug"
+	if !c.isDefinedFor(KindExporter, id) {
+		if c.Exporters.Object == nil {
+			c.Exporters.Object = map[string]interface{}{}
+		}
+		c.Exporters.Object[id] = map[string]interface{}{"verbosity": verbosity}
+		record("EnableDebugExporter", "exporter", id, "verbosity", verbosity)
+	}
+	var modified []string
+	for _, name := range c.pipelineNames() {
+		pipeline := c.Service.Pipelines[name]
+		if slices.Contains(pipeline.Exporters, id) {
+			continue
+		}
+		pipeline.Exporters = append(slices.Clone(pipeline.Exporters), id)
+		modified = append(modified, name)
+	}
+	if len(modified) > 0 {
+		record("EnableDebugExporter", "exporter", id, "pipelines", modified)
+	}
+	return nil
+}
+
+// RemoveProcessorFromPipelines removes every reference to the given processor from the pipelines, keeping its
+// definition, and returns the number of pipelines modified. RemoveComponent removes the definition too.
+func (c *Config) RemoveProcessorFromPipelines(id string) int {
+	return c.removeProcessorFromPipelines(discardMutation, id)
+}
+
+// removeProcessorFromPipelines is RemoveProcessorFromPipelines, recording the mutation with the given recorder.
+func (c *Config) removeProcessorFromPipelines(record mutationRecorder, id string) int {
+	var modified []string
+	for _, name := range c.pipelineNames() {
+		if c.Service.Pipelines[name].removeComponentID(KindProcessor, id) {
+			modified = append(modified, name)
+		}
+	}
+	if len(modified) > 0 {
+		record("RemoveProcessorFromPipelines", "processor", id, "pipelines", modified)
+	}
+	return len(modified)
+}
+
+// SortComponentLists sorts the receivers and exporters of every pipeline alphabetically, which makes rendered configs
+// easier to read. Processors run in the order they're listed, so they're only sorted when sortProcessors is set.
+func (c *Config) SortComponentLists(sortProcessors bool) {
+	for _, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		slices.Sort(pipeline.Receivers)
+		slices.Sort(pipeline.Exporters)
+		if sortProcessors {
+			slices.Sort(pipeline.Processors)
+		}
+	}
+}
+
+// CopyPipeline adds a pipeline named dst with the same components as the pipeline named src. References to the
+// component IDs found in remap are replaced by the ID they map to where that component is defined for the same use,
+// e.g. a remapped exporter reference requires the target to be defined as an exporter or a connector. This allows
+// remapping an exporter and leaving alone a receiver with the same ID. It's an error for a remapped ID not to be
+// referenced by the source pipeline, or not to be replaced anywhere. Nothing is changed if an error is returned.
+func (c *Config) CopyPipeline(src, dst string, remap map[string]string) error {
+	source, ok := c.Service.Pipelines[src]
+	if !ok || source == nil {
+		return fmt.Errorf("pipeline %s not found", src)
+	}
+	if _, ok := c.Service.Pipelines[dst]; ok {
+		return fmt.Errorf("pipeline %s already exists", dst)
+	}
+
+	referenced := map[string]bool{}
+	copyIDs := func(ids []string, kind ComponentKind) []string {
+		if ids == nil {
+			return nil
+		}
+		copied := slices.Clone(ids)
+		for i, id := range copied {
+			remapped, ok := remap[id]
+			if !ok {
+				continue
+			}
+			if c.isDefinedFor(kind, remapped) {
+				copied[i] = remapped
+				referenced[id] = true
+			} else if !referenced[id] {
+				referenced[id] = false
+			}
+		}
+		return copied
+	}
+	copied := &Pipeline{
+		Receivers:  copyIDs(source.Receivers, KindReceiver),
+		Processors: copyIDs(source.Processors, KindProcessor),
+		Exporters:  copyIDs(source.Exporters, KindExporter),
+	}
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(remap)) {
+		if replaced, ok := referenced[id]; !ok {
+			errs = append(errs, fmt.Errorf("component %s is not referenced by pipeline %s", id, src))
+		} else if !replaced {
+			errs = append(errs, fmt.Errorf("component %s is not defined for the uses of %s", remap[id], id))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if c.Service.Pipelines == nil {
+		c.Service.Pipelines = map[string]*Pipeline{}
+	}
+	c.Service.Pipelines[dst] = copied
+	return nil
+}
+
+// isDefinedFor reports whether a component with the given ID is defined for use as the given kind of component.
+// Connectors can be used as receivers and as exporters.
+func (c *Config) isDefinedFor(kind ComponentKind, id string) bool {
+	if _, ok := c.ComponentsOfKind(kind)[id]; ok {
+		return true
+	}
+	if kind == KindReceiver || kind == KindExporter {
+		_, ok := c.ComponentsOfKind(KindConnector)[id]
+		return ok
+	}
+	return false
+}
+
+// EnsurePipeline returns the pipeline with the given name, creating it with the given components if it doesn't exist.
+// An existing pipeline is returned unchanged. The components aren't required to be defined.
+func (c *Config) EnsurePipeline(name string, receivers, processors, exporters []string) *Pipeline {
+	if pipeline := c.Service.Pipelines[name]; pipeline != nil {
+		return pipeline
+	}
+	if c.Service.Pipelines == nil {
+		c.Service.Pipelines = map[string]*Pipeline{}
+	}
+	pipeline := &Pipeline{
+		Receivers:  slices.Clone(receivers),
+		Processors: slices.Clone(processors),
+		Exporters:  slices.Clone(exporters),
+	}
+	c.Service.Pipelines[name] = pipeline
+	return pipeline
+}
+
+// UnionPipeline adds the given components to the pipeline with the given name, creating it if it doesn't exist. Each
+// component is appended to its list unless the list already holds it, so that the existing components keep their order
+// and calling it again changes nothing. SetProcessors replaces the processors instead. The components aren't required
+// to be defined.
+func (c *Config) UnionPipeline(name string, receivers, processors, exporters []string) {
+	c.unionPipeline(discardMutation, name, receivers, processors, exporters)
+}
+
+// unionPipeline is UnionPipeline, recording the mutation with the given recorder.
+func (c *Config) unionPipeline(record mutationRecorder, name string, receivers, processors, exporters []string) {
+	pipeline := c.EnsurePipeline(name, nil, nil, nil)
+	before := pipeline.DeepCopy()
+	pipeline.Receivers = unionIDs(pipeline.Receivers, receivers)
+	pipeline.Processors = unionIDs(pipeline.Processors, processors)
+	pipeline.Exporters = unionIDs(pipeline.Exporters, exporters)
+	if !reflect.DeepEqual(before, pipeline) {
+		record("UnionPipeline", "pipeline", name, "old", before, "new", pipeline)
+	}
+}
+
+// unionIDs returns the given IDs with the added ones they don't hold appended, in order. The IDs are copied if any is
+// added.
+func unionIDs(ids, added []string) []string {
+	union := ids
+	for _, id := range added {
+		if slices.Contains(union, id) {
+			continue
+		}
+		if len(union) == len(ids) {
+			union = slices.Clone(ids)
+		}
+		union = append(union, id)
+	}
+	return union
+}
+
+// DisablePipeline removes the pipeline with the given name from the service and returns it, so that it can be enabled
+// again with EnablePipeline, along with whether it existed. The definitions of its components are kept.
+func (c *Config) DisablePipeline(name string) (*Pipeline, bool) {
+	pipeline, ok := c.Service.Pipelines[name]
+	if ok {
+		delete(c.Service.Pipelines, name)
+	}
+	return pipeline, ok
+}
+
+// EnablePipeline adds the given pipeline to the service under the given name, such as one removed by DisablePipeline,
+// replacing any pipeline already there.
+func (c *Config) EnablePipeline(name string, p *Pipeline) {
+	if c.Service.Pipelines == nil {
+		c.Service.Pipelines = map[string]*Pipeline{}
+	}
+	c.Service.Pipelines[name] = p
+}
@@ -0,0 +1,734 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	go_yaml "gopkg.in/yaml.v3"
+)
+
+func TestConfig_OrderedComponents(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	expected := []ComponentRef{
+		{Kind: KindReceiver, ID: "otlp", Pipelines: []string{"metrics", "traces"}},
+		{Kind: KindProcessor, ID: "batch", Pipelines: []string{"metrics", "traces"}},
+		{Kind: KindExporter, ID: "debug", Pipelines: []string{"metrics", "traces"}},
+		{Kind: KindExporter, ID: "prometheus", Pipelines: []string{"metrics"}},
+		{Kind: KindExporter, ID: "zipkin", Pipelines: []string{"traces"}},
+		{Kind: KindExporter, ID: "otlp", Pipelines: []string{"traces"}},
+	}
+	assert.Equal(t, expected, c.OrderedComponents())
+}
+
+func TestConfig_OrderedComponentsKindOrder(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Exporters:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Receivers:  []string{"jaeger"},
+				},
+				"logs": {
+					Exporters: []string{"debug"},
+					Receivers: []string{"filelog"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	var kinds []ComponentKind
+	for _, ref := range c.OrderedComponents() {
+		kinds = append(kinds, ref.Kind)
+	}
+	assert.Equal(t, []ComponentKind{KindReceiver, KindReceiver, KindProcessor, KindExporter, KindExporter}, kinds)
+}
+
+func TestConfig_SplitByPipelineType(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp":       map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}},
+					"prometheus": map[string]interface{}{"config": map[string]interface{}{}},
+				},
+			},
+			Processors: &AnyConfig{
+				Object: map[string]interface{}{
+					"batch": nil,
+				},
+			},
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp":  map[string]interface{}{"endpoint": "backend:4317"},
+					"debug": nil,
+				},
+			},
+			Connectors: &AnyConfig{
+				Object: map[string]interface{}{
+					"forward": nil,
+				},
+			},
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"health_check": nil,
+				},
+			},
+			Service: Service{
+				Extensions: []string{"health_check"},
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers:  []string{"otlp"},
+						Processors: []string{"batch"},
+						Exporters:  []string{"forward"},
+					},
+					"traces/2": {
+						Receivers: []string{"forward"},
+						Exporters: []string{"otlp"},
+					},
+					"metrics": {
+						Receivers: []string{"otlp", "prometheus"},
+						Exporters: []string{"debug"},
+					},
+				},
+			},
+		}
+	}
+
+	c := newConfig()
+	split, err := c.SplitByPipelineType()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Config{
+		"traces": {
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}},
+				},
+			},
+			Processors: &AnyConfig{
+				Object: map[string]interface{}{
+					"batch": nil,
+				},
+			},
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp": map[string]interface{}{"endpoint": "backend:4317"},
+				},
+			},
+			Connectors: &AnyConfig{
+				Object: map[string]interface{}{
+					"forward": nil,
+				},
+			},
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"health_check": nil,
+				},
+			},
+			Service: Service{
+				Extensions: []string{"health_check"},
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers:  []string{"otlp"},
+						Processors: []string{"batch"},
+						Exporters:  []string{"forward"},
+					},
+					"traces/2": {
+						Receivers: []string{"forward"},
+						Exporters: []string{"otlp"},
+					},
+				},
+			},
+		},
+		"metrics": {
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp":       map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}},
+					"prometheus": map[string]interface{}{"config": map[string]interface{}{}},
+				},
+			},
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"debug": nil,
+				},
+			},
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"health_check": nil,
+				},
+			},
+			Service: Service{
+				Extensions: []string{"health_check"},
+				Pipelines: map[string]*Pipeline{
+					"metrics": {
+						Receivers: []string{"otlp", "prometheus"},
+						Exporters: []string{"debug"},
+					},
+				},
+			},
+		},
+	}, split)
+	for pipelineType, typed := range split {
+		assert.NoError(t, typed.Validate(), pipelineType)
+		assert.NoError(t, typed.Service.Validate(*typed), pipelineType)
+	}
+	assert.Equal(t, newConfig(), c, "the config must not change")
+
+	t.Run("connector used within one signal type", func(t *testing.T) {
+		split, err := newConfig().SplitByPipelineType()
+		require.NoError(t, err)
+		require.Contains(t, split, "traces")
+		assert.Contains(t, split["traces"].ComponentsOfKind(KindConnector), "forward")
+		assert.Equal(t, []string{"forward"}, split["traces"].Service.Pipelines["traces"].Exporters)
+		assert.Equal(t, []string{"forward"}, split["traces"].Service.Pipelines["traces/2"].Receivers)
+		assert.NoError(t, split["traces"].ValidateConnectorSignalCompatibility())
+		require.Contains(t, split, "metrics")
+		assert.Nil(t, split["metrics"].Connectors, "the connector isn't used by the metrics pipelines")
+	})
+
+	t.Run("connector bridging signal types", func(t *testing.T) {
+		c := newConfig()
+		c.Service.Pipelines["metrics"].Receivers = append(c.Service.Pipelines["metrics"].Receivers, "forward")
+		_, err := c.SplitByPipelineType()
+		assert.EqualError(t, err, "connector forward bridges metrics and traces pipelines and can't be split")
+	})
+
+	t.Run("invalid signal type", func(t *testing.T) {
+		c := newConfig()
+		c.Service.Pipelines["trace"] = &Pipeline{}
+		_, err := c.SplitByPipelineType()
+		assert.EqualError(t, err, "pipeline trace has an invalid signal type trace")
+	})
+}
+
+func TestConfig_ResolvePipeline(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"batch": map[string]interface{}{
+					"timeout": "5s",
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "backend:4317",
+				},
+				"debug": nil,
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"spanmetrics": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"otlp", "spanmetrics"},
+				},
+				"metrics": {
+					Receivers: []string{"spanmetrics"},
+					Exporters: []string{"debug", "prometheus"},
+				},
+			},
+		},
+	}
+
+	resolved, err := c.ResolvePipeline("traces")
+	require.NoError(t, err)
+	assert.Equal(t, []ResolvedComponent{
+		{Kind: KindReceiver, ID: "otlp", Config: map[string]interface{}{
+			"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{},
+			},
+		}},
+		{Kind: KindProcessor, ID: "batch", Config: map[string]interface{}{"timeout": "5s"}},
+		{Kind: KindExporter, ID: "otlp", Config: map[string]interface{}{"endpoint": "backend:4317"}},
+		{Kind: KindConnector, ID: "spanmetrics", Config: map[string]interface{}{}},
+	}, resolved)
+
+	_, err = c.ResolvePipeline("metrics")
+	assert.EqualError(t, err, "exporter prometheus referenced by pipeline metrics is not defined")
+
+	_, err = c.ResolvePipeline("logs")
+	assert.EqualError(t, err, "pipeline logs not found")
+}
+
+func TestConfig_MoveProcessor(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Processors: []string{"memory_limiter", "transform", "batch"},
+					},
+					"traces/2": {
+						Processors: []string{"memory_limiter", "batch"},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		desc           string
+		id             string
+		from           string
+		to             string
+		index          int
+		expectedFrom   []string
+		expectedTo     []string
+		expectedErrStr string
+	}{
+		{
+			desc:         "move to end",
+			id:           "transform",
+			from:         "traces",
+			to:           "traces/2",
+			index:        2,
+			expectedFrom: []string{"memory_limiter", "batch"},
+			expectedTo:   []string{"memory_limiter", "batch", "transform"},
+		},
+		{
+			desc:         "move to specific index",
+			id:           "transform",
+			from:         "traces",
+			to:           "traces/2",
+			index:        1,
+			expectedFrom: []string{"memory_limiter", "batch"},
+			expectedTo:   []string{"memory_limiter", "transform", "batch"},
+		},
+		{
+			desc:         "reorder within the same pipeline",
+			id:           "batch",
+			from:         "traces",
+			to:           "traces",
+			index:        0,
+			expectedFrom: []string{"batch", "memory_limiter", "transform"},
+			expectedTo:   []string{"batch", "memory_limiter", "transform"},
+		},
+		{
+			desc:           "unknown source pipeline",
+			id:             "transform",
+			from:           "logs",
+			to:             "traces/2",
+			expectedErrStr: "pipeline logs not found",
+		},
+		{
+			desc:           "unknown target pipeline",
+			id:             "transform",
+			from:           "traces",
+			to:             "logs",
+			expectedErrStr: "pipeline logs not found",
+		},
+		{
+			desc:           "processor not in source pipeline",
+			id:             "transform",
+			from:           "traces/2",
+			to:             "traces",
+			expectedErrStr: "processor transform not found in pipeline traces/2",
+		},
+		{
+			desc:           "processor already in target pipeline",
+			id:             "batch",
+			from:           "traces",
+			to:             "traces/2",
+			expectedErrStr: "processor batch is already in pipeline traces/2",
+		},
+		{
+			desc:           "index out of range",
+			id:             "transform",
+			from:           "traces",
+			to:             "traces/2",
+			index:          3,
+			expectedErrStr: "index 3 out of range",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newConfig()
+			err := c.MoveProcessor(tt.id, tt.from, tt.to, tt.index)
+			if tt.expectedErrStr != "" {
+				assert.ErrorContains(t, err, tt.expectedErrStr)
+				assert.Equal(t, newConfig(), c, "the config must not change on errors")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFrom, c.Service.Pipelines[tt.from].Processors)
+			assert.Equal(t, tt.expectedTo, c.Service.Pipelines[tt.to].Processors)
+		})
+	}
+}
+
+func TestConfig_SetProcessors(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Processors: &AnyConfig{
+				Object: map[string]interface{}{
+					"memory_limiter": nil,
+					"transform":      nil,
+					"batch":          nil,
+				},
+			},
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Processors: []string{"batch", "memory_limiter"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		c := newConfig()
+		processors := []string{"memory_limiter", "transform", "batch"}
+		require.NoError(t, c.SetProcessors("traces", processors))
+		assert.Equal(t, []string{"memory_limiter", "transform", "batch"}, c.Service.Pipelines["traces"].Processors)
+		processors[0] = "filter"
+		assert.Equal(t, "memory_limiter", c.Service.Pipelines["traces"].Processors[0], "the list must be copied")
+	})
+
+	t.Run("undefined processors", func(t *testing.T) {
+		c := newConfig()
+		err := c.SetProcessors("traces", []string{"memory_limiter", "filter", "batch", "attributes"})
+		assert.EqualError(t, err, "processor filter is not defined\nprocessor attributes is not defined")
+		assert.Equal(t, newConfig(), c)
+	})
+
+	t.Run("missing pipeline", func(t *testing.T) {
+		c := newConfig()
+		assert.EqualError(t, c.SetProcessors("metrics", []string{"batch"}), "pipeline metrics not found")
+	})
+}
+
+func TestConfig_InsertProcessorInAllPipelines(t *testing.T) {
+	c := &Config{
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"memory_limiter": nil,
+				"batch":          nil,
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Processors: []string{"batch"}},
+				"metrics": {Processors: []string{"batch", "memory_limiter"}},
+				"logs":    {},
+				"nil":     nil,
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, c.InsertProcessorInAllPipelines("memory_limiter", true))
+		assert.Equal(t, []string{"memory_limiter", "batch"}, c.Service.Pipelines["traces"].Processors)
+		assert.Equal(t, []string{"batch", "memory_limiter"}, c.Service.Pipelines["metrics"].Processors, "a pipeline already using it is left alone")
+		assert.Equal(t, []string{"memory_limiter"}, c.Service.Pipelines["logs"].Processors)
+	}
+
+	c.Service.Pipelines["logs"].Processors = nil
+	require.NoError(t, c.InsertProcessorInAllPipelines("batch", false))
+	assert.Equal(t, []string{"memory_limiter", "batch"}, c.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{"batch"}, c.Service.Pipelines["logs"].Processors)
+
+	assert.EqualError(t, c.InsertProcessorInAllPipelines("filter", true), "processor filter is not defined")
+	assert.Equal(t, []string{"batch"}, c.Service.Pipelines["logs"].Processors)
+}
+
+func TestConfig_EnableDebugExporter(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Exporters: []string{"otlp"}},
+				"metrics": {Exporters: []string{"debug", "otlp"}},
+				"logs":    {},
+				"nil":     nil,
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, c.EnableDebugExporter("detailed"))
+		assert.Equal(t, map[string]interface{}{"debug": map[string]interface{}{"verbosity": "detailed"}}, c.Exporters.Object)
+		assert.Equal(t, []string{"otlp", "debug"}, c.Service.Pipelines["traces"].Exporters)
+		assert.Equal(t, []string{"debug", "otlp"}, c.Service.Pipelines["metrics"].Exporters, "a pipeline already using it is left alone")
+		assert.Equal(t, []string{"debug"}, c.Service.Pipelines["logs"].Exporters)
+	}
+
+	require.NoError(t, c.EnableDebugExporter("basic"))
+	assert.Equal(t, map[string]interface{}{"verbosity": "detailed"}, c.Exporters.Object["debug"], "an existing debug exporter keeps its configuration")
+
+	c.Service.Pipelines["logs"].Exporters = nil
+	assert.EqualError(t, c.EnableDebugExporter("verbose"), `invalid debug exporter verbosity "verbose", must be one of: basic, normal, detailed`)
+	assert.Empty(t, c.Service.Pipelines["logs"].Exporters)
+}
+
+func TestConfig_RemoveProcessorFromPipelines(t *testing.T) {
+	c := &Config{
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"transform": map[string]interface{}{"error_mode": "ignore"},
+				"batch":     nil,
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Processors: []string{"transform", "batch"}},
+				"metrics": {Processors: []string{"batch", "transform", "transform"}},
+				"logs":    {Processors: []string{"transform"}},
+				"logs/2":  {Processors: []string{"batch"}},
+				"nil":     nil,
+			},
+		},
+	}
+
+	assert.Equal(t, 3, c.RemoveProcessorFromPipelines("transform"))
+	assert.Equal(t, []string{"batch"}, c.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{"batch"}, c.Service.Pipelines["metrics"].Processors)
+	assert.Empty(t, c.Service.Pipelines["logs"].Processors)
+	assert.Equal(t, []string{"batch"}, c.Service.Pipelines["logs/2"].Processors)
+	assert.Contains(t, c.Processors.Object, "transform", "the definition must be kept")
+
+	assert.Equal(t, 0, c.RemoveProcessorFromPipelines("transform"))
+}
+
+func TestConfig_SortComponentLists(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers:  []string{"zipkin", "otlp", "jaeger"},
+						Processors: []string{"memory_limiter", "batch"},
+						Exporters:  []string{"otlp", "debug"},
+					},
+					"nil": nil,
+				},
+			},
+		}
+	}
+
+	c := newConfig()
+	c.SortComponentLists(false)
+	assert.Equal(t, &Pipeline{
+		Receivers:  []string{"jaeger", "otlp", "zipkin"},
+		Processors: []string{"memory_limiter", "batch"},
+		Exporters:  []string{"debug", "otlp"},
+	}, c.Service.Pipelines["traces"])
+
+	c = newConfig()
+	c.SortComponentLists(true)
+	assert.Equal(t, []string{"batch", "memory_limiter"}, c.Service.Pipelines["traces"].Processors)
+}
+
+func TestConfig_CopyPipeline(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp": map[string]interface{}{},
+				},
+			},
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"otlp":        map[string]interface{}{},
+					"otlp/canary": map[string]interface{}{},
+				},
+			},
+			Processors: &AnyConfig{
+				Object: map[string]interface{}{
+					"batch": map[string]interface{}{},
+				},
+			},
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers:  []string{"otlp"},
+						Processors: []string{"batch"},
+						Exporters:  []string{"otlp"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("with a remapped exporter", func(t *testing.T) {
+		c := newConfig()
+		require.NoError(t, c.CopyPipeline("traces", "traces/canary", map[string]string{"otlp": "otlp/canary"}))
+		assert.Equal(t, &Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+			Exporters:  []string{"otlp/canary"},
+		}, c.Service.Pipelines["traces/canary"])
+		assert.Equal(t, newConfig().Service.Pipelines["traces"], c.Service.Pipelines["traces"])
+
+		// the copy must not share its lists with the source
+		c.Service.Pipelines["traces/canary"].Processors[0] = "memory_limiter"
+		assert.Equal(t, []string{"batch"}, c.Service.Pipelines["traces"].Processors)
+	})
+
+	for _, tt := range []struct {
+		desc        string
+		src         string
+		dst         string
+		remap       map[string]string
+		expectedErr string
+	}{
+		{
+			desc:        "unknown source",
+			src:         "logs",
+			dst:         "logs/canary",
+			expectedErr: "pipeline logs not found",
+		},
+		{
+			desc:        "existing destination",
+			src:         "traces",
+			dst:         "traces",
+			expectedErr: "pipeline traces already exists",
+		},
+		{
+			desc:        "undefined remapped component",
+			src:         "traces",
+			dst:         "traces/canary",
+			remap:       map[string]string{"otlp": "otlp/undefined"},
+			expectedErr: "component otlp/undefined is not defined for the uses of otlp",
+		},
+		{
+			desc:        "remapped component not referenced",
+			src:         "traces",
+			dst:         "traces/canary",
+			remap:       map[string]string{"otlp": "otlp/canary", "jaeger": "otlp/canary"},
+			expectedErr: "component jaeger is not referenced by pipeline traces",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newConfig()
+			assert.EqualError(t, c.CopyPipeline(tt.src, tt.dst, tt.remap), tt.expectedErr)
+			assert.Equal(t, newConfig(), c)
+		})
+	}
+}
+
+func TestConfig_PipelineType(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":    {},
+				"metrics/2": {},
+				"trace":     {},
+			},
+		},
+	}
+
+	assert.Equal(t, PipelineTypeTraces, c.PipelineType("traces"))
+	assert.Equal(t, PipelineTypeMetrics, c.PipelineType("metrics/2"))
+	assert.Empty(t, c.PipelineType("trace"))
+	assert.Empty(t, c.PipelineType("logs"))
+}
+
+func TestValidPipelineType(t *testing.T) {
+	for _, pipelineType := range []string{"traces", "metrics", "logs", "profiles"} {
+		assert.True(t, ValidPipelineType(pipelineType), pipelineType)
+	}
+	for _, pipelineType := range []string{"trace", "metrics/2", ""} {
+		assert.False(t, ValidPipelineType(pipelineType), pipelineType)
+	}
+}
+
+func TestConfig_EnsurePipeline(t *testing.T) {
+	c := &Config{}
+
+	created := c.EnsurePipeline("traces", []string{"otlp"}, []string{"batch"}, []string{"debug"})
+	assert.Equal(t, &Pipeline{
+		Receivers:  []string{"otlp"},
+		Processors: []string{"batch"},
+		Exporters:  []string{"debug"},
+	}, created)
+	assert.Same(t, created, c.Service.Pipelines["traces"])
+
+	existing := c.EnsurePipeline("traces", []string{"jaeger"}, nil, []string{"otlp"})
+	assert.Same(t, created, existing)
+	assert.Equal(t, []string{"otlp"}, existing.Receivers)
+	assert.Equal(t, []string{"debug"}, existing.Exporters)
+}
+
+func TestConfig_UnionPipeline(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"memory_limiter", "batch"},
+					Exporters:  []string{"debug"},
+				},
+			},
+		},
+	}
+	original := c.Service.Pipelines["traces"]
+
+	for i := 0; i < 2; i++ {
+		c.UnionPipeline("traces", []string{"jaeger", "otlp", "jaeger"}, []string{"batch", "transform"}, nil)
+		assert.Same(t, original, c.Service.Pipelines["traces"])
+		assert.Equal(t, &Pipeline{
+			Receivers:  []string{"otlp", "jaeger"},
+			Processors: []string{"memory_limiter", "batch", "transform"},
+			Exporters:  []string{"debug"},
+		}, c.Service.Pipelines["traces"])
+	}
+
+	c.UnionPipeline("logs", []string{"filelog"}, nil, []string{"otlp", "otlp"})
+	assert.Equal(t, &Pipeline{
+		Receivers: []string{"filelog"},
+		Exporters: []string{"otlp"},
+	}, c.Service.Pipelines["logs"])
+}
+
+func TestConfig_DisablePipeline(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+	original := c.Clone()
+
+	pipeline, ok := c.DisablePipeline("traces")
+	require.True(t, ok)
+	assert.Equal(t, original.Service.Pipelines["traces"], pipeline)
+	assert.NotContains(t, c.Service.Pipelines, "traces")
+	assert.Equal(t, original.Receivers, c.Receivers, "the components must be kept")
+	assert.Equal(t, original.Exporters, c.Exporters, "the components must be kept")
+
+	_, ok = c.DisablePipeline("traces")
+	assert.False(t, ok)
+
+	c.EnablePipeline("traces", pipeline)
+	assert.Equal(t, original, c)
+
+	empty := &Config{}
+	empty.EnablePipeline("logs", &Pipeline{Receivers: []string{"otlp"}})
+	assert.Equal(t, map[string]*Pipeline{"logs": {Receivers: []string{"otlp"}}}, empty.Service.Pipelines)
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pathSegmentRegex matches a segment of a dotted path: a key optionally followed by array indices, e.g. "exporters[0]".
+var pathSegmentRegex = regexp.MustCompile(`^([^\[\]]+)((?:\[\d+\])*)$`)
+
+// pathIndexRegex matches the array indices of a path segment.
+var pathIndexRegex = regexp.MustCompile(`\[(\d+)\]`)
+
+// pathStep is a step of a dotted path, either a map key or an array index.
+type pathStep struct {
+	key   string
+	index int
+	// isIndex tells whether the step is an array index rather than a map key.
+	isIndex bool
+}
+
+// parsePath splits a dotted path, such as "service.pipelines.traces.exporters[0]", into its steps.
+func parsePath(path string) ([]pathStep, error) {
+	var steps []pathStep
+	for _, segment := range strings.Split(path, ".") {
+		matches := pathSegmentRegex.FindStringSubmatch(segment)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid path %q: invalid segment %q", path, segment)
+		}
+		steps = append(steps, pathStep{key: matches[1]})
+		for _, index := range pathIndexRegex.FindAllStringSubmatch(matches[2], -1) {
+			i, err := strconv.Atoi(index[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+			steps = append(steps, pathStep{index: i, isIndex: true})
+		}
+	}
+	return steps, nil
+}
+
+// ApplySet sets the value at the given dotted path, like the collector's --set flag does, e.g.
+// "receivers.otlp.protocols.grpc.endpoint". A segment may index an array, e.g. "service.pipelines.traces.exporters[0]",
+// and an index equal to the length of the array appends to it. The missing maps along the path are created. An error is
+// returned if the path descends into a scalar, if an index is out of range, or if the value doesn't fit the service's
+// structure. The config is left untouched on errors.
+func (c *Config) ApplySet(path string, value interface{}) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	top := steps[0]
+	if top.key == "service" {
+		return c.Service.applySet(path, steps[1:], value)
+	}
+	for _, kind := range AllComponentKinds() {
+		if top.key != kind.String()+"s" {
+			continue
+		}
+		section := c.componentSection(kind)
+		var current interface{}
+		if section != nil && section.Object != nil {
+			current = section.Object
+		}
+		updated, err := setPathValue(current, steps[1:], value, top.key)
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		object, ok := updated.(map[string]interface{})
+		if !ok && updated != nil {
+			return fmt.Errorf("cannot set %s: %s must be a map", path, top.key)
+		}
+		c.setComponentSection(kind, object)
+		return nil
+	}
+	return fmt.Errorf("cannot set %s: unknown top-level key %q, must be one of: %s", path, top.key, strings.Join(topLevelKeys, ", "))
+}
+
+// setComponentSection replaces the definitions of the given kind of components, creating the optional section if
+// needed.
+func (c *Config) setComponentSection(kind ComponentKind, object map[string]interface{}) {
+	switch kind {
+	case KindReceiver:
+		c.Receivers.Object = object
+	case KindExporter:
+		c.Exporters.Object = object
+	case KindProcessor:
+		c.Processors = &AnyConfig{Object: object}
+	case KindExtension:
+		c.Extensions = &AnyConfig{Object: object}
+	case KindConnector:
+		c.Connectors = &AnyConfig{Object: object}
+	}
+}
+
+// applySet sets the value at the given steps within the service. The telemetry is free-form and is set in place, like
+// the components are, while the rest of the service is converted to its generic form to be navigated, and back, so
+// that the value is checked against the service's structure. The telemetry is kept as it is when setting the rest.
+func (s *Service) applySet(path string, steps []pathStep, value interface{}) error {
+	if len(steps) > 0 && !steps[0].isIndex && steps[0].key == "telemetry" {
+		var current interface{}
+		if s.Telemetry != nil && s.Telemetry.Object != nil {
+			current = s.Telemetry.Object
+		}
+		updated, err := setPathValue(current, steps[1:], value, "service.telemetry")
+		if err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+		object, ok := updated.(map[string]interface{})
+		if !ok && updated != nil {
+			return fmt.Errorf("cannot set %s: service.telemetry must be a map", path)
+		}
+		s.Telemetry = &AnyConfig{Object: object}
+		return nil
+	}
+	structured := *s
+	if len(steps) > 0 {
+		structured.Telemetry = nil
+	}
+	current, err := toGeneric(&structured)
+	if err != nil {
+		return err
+	}
+	updated, err := setPathValue(current, steps, value, "service")
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	data, err := yaml.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	service := Service{}
+	if err := decoder.Decode(&service); err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	if len(steps) > 0 {
+		service.Telemetry = s.Telemetry
+	}
+	*s = service
+	return nil
+}
+
+// toGeneric converts the given value to its generic form, made of maps, arrays and scalars, as it would be decoded from
+// its YAML representation.
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// setPathValue sets the value at the given steps within the current value, which is reached by the given path, and
+// returns the updated value. Missing maps are created, the existing maps and arrays are updated in place.
+func setPathValue(current interface{}, steps []pathStep, value interface{}, at string) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	step := steps[0]
+	if step.isIndex {
+		var items []interface{}
+		switch val := current.(type) {
+		case nil:
+		case []interface{}:
+			items = val
+		default:
+			return nil, fmt.Errorf("%s is a %T, not an array", at, current)
+		}
+		if step.index > len(items) {
+			return nil, fmt.Errorf("index %d out of range for %s of length %d", step.index, at, len(items))
+		}
+		if step.index == len(items) {
+			items = append(items, nil)
+		}
+		at = fmt.Sprintf("%s[%d]", at, step.index)
+		updated, err := setPathValue(items[step.index], steps[1:], value, at)
+		if err != nil {
+			return nil, err
+		}
+		items[step.index] = updated
+		return items, nil
+	}
+
+	var m map[string]interface{}
+	switch val := current.(type) {
+	case nil:
+		m = map[string]interface{}{}
+	case map[string]interface{}:
+		m = val
+	default:
+		return nil, fmt.Errorf("%s is a %T, not a map", at, current)
+	}
+	updated, err := setPathValue(m[step.key], steps[1:], value, at+"."+step.key)
+	if err != nil {
+		return nil, err
+	}
+	m[step.key] = updated
+	return m, nil
+}
+
+// getPathValue returns the value at the given steps within the current value, and whether there's one.
+func getPathValue(current interface{}, steps []pathStep) (interface{}, bool) {
+	for _, step := range steps {
+		if step.isIndex {
+			items, ok := current.([]interface{})
+			if !ok || step.index >= len(items) {
+				return nil, false
+			}
+			current = items[step.index]
+			continue
+		}
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[step.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// deletePathValue removes the value at the given steps within the current value, and reports whether there was one. A
+// map entry is deleted, while an array element is set to nil so that the indices of the other elements are kept.
+func deletePathValue(current interface{}, steps []pathStep) bool {
+	parent, ok := getPathValue(current, steps[:len(steps)-1])
+	if !ok {
+		return false
+	}
+	last := steps[len(steps)-1]
+	if last.isIndex {
+		items, ok := parent.([]interface{})
+		if !ok || last.index >= len(items) {
+			return false
+		}
+		items[last.index] = nil
+		return true
+	}
+	m, ok := parent.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, ok := m[last.key]; !ok {
+		return false
+	}
+	delete(m, last.key)
+	return true
+}
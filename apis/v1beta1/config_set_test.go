@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newApplySetConfig() *Config {
+	return &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": nil,
+					},
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": map[string]interface{}{
+					"verbosity": "basic",
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+}
+
+func TestConfig_ApplySet(t *testing.T) {
+	t.Run("scalar", func(t *testing.T) {
+		c := newApplySetConfig()
+		require.NoError(t, c.ApplySet("receivers.otlp.protocols.grpc.endpoint", "0.0.0.0:4317"))
+		assert.Equal(t, map[string]interface{}{
+			"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{
+					"endpoint": "0.0.0.0:4317",
+				},
+			},
+		}, c.Receivers.Object["otlp"])
+	})
+
+	t.Run("missing section", func(t *testing.T) {
+		c := newApplySetConfig()
+		require.NoError(t, c.ApplySet("processors.batch.timeout", "5s"))
+		assert.Equal(t, map[string]interface{}{
+			"batch": map[string]interface{}{
+				"timeout": "5s",
+			},
+		}, c.Processors.Object)
+	})
+
+	t.Run("array index", func(t *testing.T) {
+		c := newApplySetConfig()
+		require.NoError(t, c.ApplySet("service.pipelines.traces.exporters[0]", "otlp"))
+		require.NoError(t, c.ApplySet("service.pipelines.traces.exporters[1]", "debug"))
+		assert.Equal(t, []string{"otlp", "debug"}, c.Service.Pipelines["traces"].Exporters)
+		assert.Equal(t, []string{"otlp"}, c.Service.Pipelines["traces"].Receivers)
+	})
+
+	t.Run("telemetry", func(t *testing.T) {
+		c := newApplySetConfig()
+		require.NoError(t, c.ApplySet("service.telemetry.metrics.level", "detailed"))
+		level, err := c.Service.MetricsLevel()
+		require.NoError(t, err)
+		assert.Equal(t, "detailed", level)
+	})
+
+	t.Run("untouched service values keep their types", func(t *testing.T) {
+		c := newApplySetConfig()
+		c.Service.Telemetry = &AnyConfig{
+			Object: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"sampling": map[string]interface{}{"initial": float64(10)},
+				},
+			},
+		}
+		require.NoError(t, c.ApplySet("service.telemetry.metrics.level", "detailed"))
+		require.NoError(t, c.ApplySet("service.pipelines.traces.exporters[1]", "debug"))
+		assert.Equal(t, map[string]interface{}{
+			"logs": map[string]interface{}{
+				"sampling": map[string]interface{}{"initial": float64(10)},
+			},
+			"metrics": map[string]interface{}{"level": "detailed"},
+		}, c.Service.Telemetry.Object)
+	})
+
+	for _, tt := range []struct {
+		desc        string
+		path        string
+		value       interface{}
+		expectedErr string
+	}{
+		{
+			desc:        "descending into a scalar",
+			path:        "exporters.debug.verbosity.level",
+			value:       "detailed",
+			expectedErr: "cannot set exporters.debug.verbosity.level: exporters.debug.verbosity is a string, not a map",
+		},
+		{
+			desc:        "indexing a map",
+			path:        "exporters.debug[0]",
+			value:       "detailed",
+			expectedErr: "cannot set exporters.debug[0]: exporters.debug is a map[string]interface {}, not an array",
+		},
+		{
+			desc:        "index out of range",
+			path:        "service.pipelines.traces.exporters[2]",
+			value:       "otlp",
+			expectedErr: "cannot set service.pipelines.traces.exporters[2]: index 2 out of range for service.pipelines.traces.exporters of length 1",
+		},
+		{
+			desc:        "value not fitting the service",
+			path:        "service.pipelines.traces.exporters",
+			value:       "otlp",
+			expectedErr: "cannot unmarshal !!str `otlp` into []string",
+		},
+		{
+			desc:        "unknown top-level key",
+			path:        "recievers.otlp",
+			expectedErr: `cannot set recievers.otlp: unknown top-level key "recievers", must be one of: receivers, exporters, processors, connectors, extensions, service`,
+		},
+		{
+			desc:        "invalid path",
+			path:        "receivers..otlp",
+			expectedErr: `invalid path "receivers..otlp": invalid segment ""`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newApplySetConfig()
+			assert.ErrorContains(t, c.ApplySet(tt.path, tt.value), tt.expectedErr)
+			assert.Equal(t, newApplySetConfig(), c, "the config must not change on errors")
+		})
+	}
+}
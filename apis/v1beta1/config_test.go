@@ -15,9 +15,15 @@ import (
 	"github.com/stretchr/testify/require"
 	go_yaml "gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/connectors"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/extensions"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
 )
 
 func TestConfigFiles(t *testing.T) {
@@ -82,6 +88,59 @@ func TestNullObjects_issue_3445(t *testing.T) {
 	assert.Empty(t, cfg.nullObjects())
 }
 
+func TestNullObjects_TelemetryResource(t *testing.T) {
+	collectorYaml := []byte(`
+service:
+  telemetry:
+    resource:
+      service.version: null
+    logs: null
+  pipelines: {}
+`)
+	collectorJson, err := yaml.YAMLToJSON(collectorYaml)
+	require.NoError(t, err)
+
+	cfg := &Config{}
+	err = json.Unmarshal(collectorJson, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"service.telemetry.logs:"}, cfg.nullObjects())
+}
+
+// scalarDefaultParser is a parser whose default configuration is a scalar rather than a map.
+type scalarDefaultParser struct {
+	components.Parser
+}
+
+func (scalarDefaultParser) GetDefaultConfig(logr.Logger, interface{}) (interface{}, error) {
+	return "default", nil
+}
+
+func TestApplyDefaults_ScalarDefault(t *testing.T) {
+	receivers.Register("scalardefault", scalarDefaultParser{
+		Parser: components.NewBuilder[any]().WithName("scalardefault").MustBuild(),
+	})
+
+	cfg := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"scalardefault":      nil,
+				"scalardefault/user": "configured",
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"scalardefault", "scalardefault/user"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.ApplyDefaults(logr.Discard()))
+	assert.Equal(t, "default", cfg.Receivers.Object["scalardefault"])
+	assert.Equal(t, "configured", cfg.Receivers.Object["scalardefault/user"])
+}
+
 func TestConfigFiles_go_yaml(t *testing.T) {
 	files, err := os.ReadDir("./testdata")
 	require.NoError(t, err)
@@ -195,6 +254,96 @@ service:
 	assert.Equal(t, expected, yamlCollector)
 }
 
+func TestComponentKind(t *testing.T) {
+	assert.Equal(t, []ComponentKind{KindReceiver, KindProcessor, KindExporter, KindExtension, KindConnector}, AllComponentKinds())
+	for kind, expected := range map[ComponentKind]string{
+		KindReceiver:  "receiver",
+		KindProcessor: "processor",
+		KindExporter:  "exporter",
+		KindExtension: "extension",
+		KindConnector: "connector",
+	} {
+		assert.Equal(t, expected, kind.String())
+		assert.True(t, kind.IsValid(), expected)
+	}
+	assert.False(t, ComponentKind(-1).IsValid())
+	assert.False(t, ComponentKind(len(AllComponentKinds())).IsValid())
+}
+
+func TestConfigFromYAMLStrict(t *testing.T) {
+	collectorYaml, err := os.ReadFile("./testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	expected := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, expected))
+	c, err := ConfigFromYAMLStrict(collectorYaml)
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	c, err = ConfigFromYAMLStrict([]byte(`receivers:
+  otlp:
+    unknown_field: true
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`))
+	require.NoError(t, err, "the content of the components isn't checked")
+	assert.Equal(t, map[string]interface{}{"unknown_field": true}, c.Receivers.Object["otlp"])
+
+	_, err = ConfigFromYAMLStrict([]byte(`recievers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`))
+	assert.EqualError(t, err, `line 1: unknown top-level key "recievers", must be one of: receivers, exporters, processors, connectors, extensions, service`)
+
+	_, err = ConfigFromYAMLStrict([]byte(`- receivers`))
+	assert.EqualError(t, err, "line 1: config must be a mapping")
+}
+
+func TestRenderConfigTemplate(t *testing.T) {
+	const tmpl = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    {{ .Pipeline }}:
+      receivers: [otlp]
+      exporters: [debug]
+  telemetry:
+    metrics:
+      level: {{ .Level }}
+`
+
+	c, err := RenderConfigTemplate(tmpl, map[string]string{"Pipeline": "metrics/app", "Level": "basic"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Pipeline{
+		"metrics/app": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+	}, c.Service.Pipelines)
+
+	_, err = RenderConfigTemplate(tmpl, map[string]string{"Pipeline": "traces"})
+	assert.ErrorContains(t, err, "failed to render the config template: template: config:14:")
+	assert.ErrorContains(t, err, `map has no entry for key "Level"`)
+
+	_, err = RenderConfigTemplate(tmpl, map[string]string{"Pipeline": "traces", "Level": "verbose"})
+	assert.EqualError(t, err, `invalid rendered config: unknown telemetry metrics level "verbose", must be one of: none, basic, normal, detailed`)
+
+	_, err = RenderConfigTemplate("{{ .Pipeline ", nil)
+	assert.ErrorContains(t, err, "invalid config template: template: config:1:")
+
+	_, err = RenderConfigTemplate("recievers:\n  {{ .Receiver }}:\n", map[string]string{"Receiver": "otlp"})
+	assert.EqualError(t, err, `invalid rendered config: line 1: unknown top-level key "recievers", must be one of: receivers, exporters, processors, connectors, extensions, service`)
+}
+
 func TestGetTelemetryFromYAML(t *testing.T) {
 	collectorYaml, err := os.ReadFile("./testdata/otelcol-demo.yaml")
 	require.NoError(t, err)
@@ -211,6 +360,246 @@ func TestGetTelemetryFromYAML(t *testing.T) {
 	assert.Equal(t, telemetry, cfg.Service.GetTelemetry())
 }
 
+func TestService_SetTelemetryResource(t *testing.T) {
+	cfg := &Config{
+		Service: Service{
+			Telemetry: &AnyConfig{
+				Object: map[string]interface{}{
+					"resource": map[string]interface{}{
+						"k8s.cluster.name": "prod",
+					},
+				},
+			},
+			Pipelines: map[string]*Pipeline{},
+		},
+	}
+	cfg.Service.SetTelemetryResource(map[string]*string{
+		"service.version":   nil,
+		"service.namespace": ptr.To("observability"),
+		"host.name":         ptr.To("collector"),
+	})
+
+	for i := 0; i < 10; i++ {
+		yamlCfg, err := cfg.Yaml()
+		require.NoError(t, err)
+		assert.Contains(t, yamlCfg, `  telemetry:
+    resource:
+      host.name: collector
+      k8s.cluster.name: prod
+      service.namespace: observability
+      service.version: null
+`)
+	}
+
+	telemetry := cfg.Service.GetTelemetry()
+	require.NotNil(t, telemetry)
+	assert.Equal(t, map[string]*string{
+		"host.name":         ptr.To("collector"),
+		"k8s.cluster.name":  ptr.To("prod"),
+		"service.namespace": ptr.To("observability"),
+		"service.version":   nil,
+	}, telemetry.Resource)
+}
+
+func TestService_MergeResource(t *testing.T) {
+	newService := func() *Service {
+		return &Service{
+			Telemetry: &AnyConfig{
+				Object: map[string]interface{}{
+					"resource": map[string]interface{}{
+						"k8s.cluster.name": "prod",
+						"service.version":  nil,
+					},
+				},
+			},
+		}
+	}
+	for _, tt := range []struct {
+		desc              string
+		attributes        map[string]*string
+		overwrite         bool
+		expectedConflicts []string
+		expectedResource  map[string]*string
+	}{
+		{
+			desc: "no conflict",
+			attributes: map[string]*string{
+				"k8s.cluster.name": ptr.To("prod"),
+				"service.version":  nil,
+				"host.name":        ptr.To("collector"),
+			},
+			expectedResource: map[string]*string{
+				"host.name":        ptr.To("collector"),
+				"k8s.cluster.name": ptr.To("prod"),
+				"service.version":  nil,
+			},
+		},
+		{
+			desc: "conflicts kept",
+			attributes: map[string]*string{
+				"k8s.cluster.name": ptr.To("staging"),
+				"service.version":  ptr.To("1.0.0"),
+				"host.name":        ptr.To("collector"),
+			},
+			expectedConflicts: []string{"k8s.cluster.name", "service.version"},
+			expectedResource: map[string]*string{
+				"host.name":        ptr.To("collector"),
+				"k8s.cluster.name": ptr.To("prod"),
+				"service.version":  nil,
+			},
+		},
+		{
+			desc: "conflicts overwritten",
+			attributes: map[string]*string{
+				"k8s.cluster.name": nil,
+				"service.version":  ptr.To("1.0.0"),
+			},
+			overwrite:         true,
+			expectedConflicts: []string{"k8s.cluster.name", "service.version"},
+			expectedResource: map[string]*string{
+				"k8s.cluster.name": nil,
+				"service.version":  ptr.To("1.0.0"),
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := newService()
+			conflicts, err := s.MergeResource(tt.attributes, tt.overwrite)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedConflicts, conflicts)
+			telemetry := s.GetTelemetry()
+			require.NotNil(t, telemetry)
+			assert.Equal(t, tt.expectedResource, telemetry.Resource)
+		})
+	}
+
+	t.Run("no telemetry", func(t *testing.T) {
+		s := &Service{}
+		conflicts, err := s.MergeResource(map[string]*string{"host.name": ptr.To("collector")}, false)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, map[string]interface{}{"host.name": "collector"}, s.Telemetry.Object["resource"])
+	})
+
+	t.Run("invalid resource", func(t *testing.T) {
+		s := &Service{Telemetry: &AnyConfig{Object: map[string]interface{}{"resource": "prod"}}}
+		_, err := s.MergeResource(map[string]*string{"host.name": ptr.To("collector")}, false)
+		assert.EqualError(t, err, "service.telemetry.resource is a string, not a map")
+		assert.Equal(t, "prod", s.Telemetry.Object["resource"])
+	})
+}
+
+func TestService_ResolvedResourceAttributes(t *testing.T) {
+	defaults := map[string]string{
+		"service.name":        "otelcol",
+		"service.version":     "0.120.0",
+		"service.instance.id": "a1b2",
+	}
+	s := &Service{
+		Telemetry: &AnyConfig{
+			Object: map[string]interface{}{
+				"resource": map[string]interface{}{
+					"service.name":        "gateway",
+					"service.instance.id": nil,
+					"k8s.cluster.name":    "prod",
+					"host.name":           nil,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"service.name":     "gateway",
+		"service.version":  "0.120.0",
+		"k8s.cluster.name": "prod",
+	}, s.ResolvedResourceAttributes(defaults))
+	assert.Len(t, defaults, 3, "the defaults must not be modified")
+
+	assert.Equal(t, defaults, (&Service{}).ResolvedResourceAttributes(defaults))
+	assert.Equal(t, map[string]string{}, (&Service{}).ResolvedResourceAttributes(nil))
+}
+
+func TestService_SetMetricsAddress(t *testing.T) {
+	for _, tt := range []struct {
+		desc            string
+		host            string
+		port            int32
+		expectedAddress string
+		expectedHost    string
+	}{
+		{"ipv4", "0.0.0.0", 9090, "0.0.0.0:9090", "0.0.0.0"},
+		{"ipv6", "::1", 9090, "[::1]:9090", "[::1]"},
+		{"bracketed ipv6", "[::]", 8888, "[::]:8888", "[::]"},
+		{"env var", "${env:POD_IP}", 8888, "${env:POD_IP}:8888", "${env:POD_IP}"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := &Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "detailed",
+						},
+					},
+				},
+			}
+			s.SetMetricsAddress(tt.host, tt.port)
+			assert.Equal(t, map[string]interface{}{
+				"level":   "detailed",
+				"address": tt.expectedAddress,
+			}, s.Telemetry.Object["metrics"])
+
+			host, port, err := s.MetricsEndpoint(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHost, host)
+			assert.Equal(t, tt.port, port)
+		})
+	}
+
+	s := &Service{}
+	s.SetMetricsAddress("localhost", 8888)
+	assert.Equal(t, map[string]interface{}{"address": "localhost:8888"}, s.Telemetry.Object["metrics"])
+}
+
+func TestService_MetricsEnabled(t *testing.T) {
+	for _, tt := range []struct {
+		level           string
+		expectedEnabled bool
+	}{
+		{level: "none"},
+		{level: "basic", expectedEnabled: true},
+		{level: "", expectedEnabled: true},
+	} {
+		t.Run(tt.level, func(t *testing.T) {
+			s := &Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level":   tt.level,
+							"address": "0.0.0.0:9090",
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.expectedEnabled, s.MetricsEnabled())
+
+			// the endpoint is resolved either way, the callers exposing the metrics check MetricsEnabled
+			_, port, err := s.MetricsEndpoint(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, int32(9090), port)
+
+			c := &Config{Service: *s}
+			targets, err := c.ScrapeTargets(logr.Discard())
+			require.NoError(t, err)
+			if tt.expectedEnabled {
+				require.Len(t, targets, 1)
+				assert.Equal(t, int32(9090), targets[0].Port)
+			} else {
+				assert.Empty(t, targets)
+			}
+		})
+	}
+}
+
 func TestGetTelemetryFromYAMLIsNil(t *testing.T) {
 	collectorYaml, err := os.ReadFile("./testdata/otelcol-couchbase.yaml")
 	require.NoError(t, err)
@@ -257,6 +646,80 @@ func TestConfigMetricsEndpoint(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:         "quoted address",
+			expectedAddr: "0.0.0.0",
+			expectedPort: 8888,
+			config: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"address": `"0.0.0.0:8888"`,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:         "single quoted ipv6 address",
+			expectedAddr: "[::1]",
+			expectedPort: 9090,
+			config: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"address": "'[::1]:9090'",
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:         "prometheus reader",
+			expectedAddr: "[::]",
+			expectedPort: 9090,
+			config: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"readers": []interface{}{
+								map[string]interface{}{
+									"periodic": map[string]interface{}{
+										"exporter": map[string]interface{}{"otlp": map[string]interface{}{}},
+									},
+								},
+								map[string]interface{}{
+									"pull": map[string]interface{}{
+										"exporter": map[string]interface{}{
+											"prometheus": map[string]interface{}{"host": "::", "port": 9090},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:        "readers without a prometheus reader",
+			expectedErr: true,
+			config: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"readers": []interface{}{
+								map[string]interface{}{
+									"periodic": map[string]interface{}{
+										"exporter": map[string]interface{}{"otlp": map[string]interface{}{}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc:         "missing port",
 			expectedAddr: "localhost",
@@ -420,6 +883,13 @@ func TestConfigMetricsEndpoint(t *testing.T) {
 			}
 			assert.Equal(t, tt.expectedAddr, addr)
 			assert.Equal(t, tt.expectedPort, port)
+
+			host, hostErr := tt.config.MetricsHost(logger)
+			assert.Equal(t, addr, host)
+			assert.Equal(t, err, hostErr)
+			port, portErr := tt.config.MetricsPort(logger)
+			assert.Equal(t, tt.expectedPort, port)
+			assert.Equal(t, err, portErr)
 		})
 	}
 }
@@ -445,6 +915,9 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 					"count": struct{}{},
 				},
 				KindExtension: {},
+				KindConnector: {
+					"count": struct{}{},
+				},
 			},
 		},
 		{
@@ -463,6 +936,7 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 					"prometheus": struct{}{},
 				},
 				KindExtension: {},
+				KindConnector: {},
 			},
 		},
 		{
@@ -486,6 +960,7 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 					"pprof":        struct{}{},
 					"zpages":       struct{}{},
 				},
+				KindConnector: {},
 			},
 		},
 		{
@@ -502,6 +977,7 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 				KindExtension: {
 					"oauth2client": struct{}{},
 				},
+				KindConnector: {},
 			},
 		},
 		{
@@ -516,6 +992,7 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 					"debug": struct{}{},
 				},
 				KindExtension: {},
+				KindConnector: {},
 			},
 		},
 		{
@@ -526,6 +1003,7 @@ func TestConfig_GetEnabledComponents(t *testing.T) {
 				KindProcessor: {},
 				KindExporter:  {},
 				KindExtension: {},
+				KindConnector: {},
 			},
 		},
 	}
@@ -758,3 +1236,1022 @@ func TestConfig_GetExporterPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestService_MetricsLevel(t *testing.T) {
+	for _, tt := range []struct {
+		desc          string
+		service       Service
+		expectedLevel string
+		expectedErr   bool
+	}{
+		{
+			desc:          "missing telemetry",
+			expectedLevel: "normal",
+		},
+		{
+			desc: "missing level",
+			service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"address": "0.0.0.0:8888",
+						},
+					},
+				},
+			},
+			expectedLevel: "normal",
+		},
+		{
+			desc: "valid level",
+			service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "detailed",
+						},
+					},
+				},
+			},
+			expectedLevel: "detailed",
+		},
+		{
+			desc: "valid level mixed case",
+			service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "Basic",
+						},
+					},
+				},
+			},
+			expectedLevel: "basic",
+		},
+		{
+			desc: "invalid level",
+			service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "verbose",
+						},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			level, err := tt.service.MetricsLevel()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLevel, level)
+		})
+	}
+}
+
+// withExtensionParsers makes the given extension parsers retrievable until the end of the test, without registering
+// them for the other tests.
+func withExtensionParsers(t *testing.T, parsers map[string]components.Parser) {
+	original := parserRetrievers
+	t.Cleanup(func() {
+		parserRetrievers = original
+	})
+	parserRetrievers.Extensions = func(name string) components.Parser {
+		if parser, ok := parsers[components.ComponentType(name)]; ok {
+			return parser
+		}
+		return original.Extensions(name)
+	}
+}
+
+func TestConfig_GetAllEnvironmentVariables(t *testing.T) {
+	kubeletstatsEnvVar := v1.EnvVar{Name: "K8S_NODE_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}}
+	extensionEnvVar := v1.EnvVar{Name: "EXTENSION_TOKEN", Value: "secret"}
+	withExtensionParsers(t, map[string]components.Parser{
+		"envextension": components.NewBuilder[any]().WithName("envextension").
+			WithEnvVarGen(func(logr.Logger, any) ([]v1.EnvVar, error) {
+				return []v1.EnvVar{extensionEnvVar}, nil
+			}).MustBuild(),
+		"conflictingextension": components.NewBuilder[any]().WithName("conflictingextension").
+			WithEnvVarGen(func(logr.Logger, any) ([]v1.EnvVar, error) {
+				return []v1.EnvVar{{Name: "K8S_NODE_NAME", Value: "static"}, extensionEnvVar}, nil
+			}).MustBuild(),
+	})
+
+	tests := []struct {
+		name        string
+		config      *Config
+		want        []v1.EnvVar
+		expectedErr string
+	}{
+		{
+			name: "receiver and extension",
+			config: &Config{
+				Receivers: AnyConfig{
+					Object: map[string]interface{}{
+						"kubeletstats":   map[string]interface{}{},
+						"kubeletstats/2": map[string]interface{}{},
+					},
+				},
+				Extensions: &AnyConfig{
+					Object: map[string]interface{}{
+						"envextension": map[string]interface{}{},
+					},
+				},
+				Service: Service{
+					Extensions: []string{"envextension"},
+					Pipelines: map[string]*Pipeline{
+						"metrics": {
+							Receivers: []string{"kubeletstats", "kubeletstats/2"},
+						},
+					},
+				},
+			},
+			want: []v1.EnvVar{extensionEnvVar, kubeletstatsEnvVar},
+		},
+		{
+			name: "conflicting values",
+			config: &Config{
+				Receivers: AnyConfig{
+					Object: map[string]interface{}{
+						"kubeletstats": map[string]interface{}{},
+					},
+				},
+				Extensions: &AnyConfig{
+					Object: map[string]interface{}{
+						"conflictingextension": map[string]interface{}{},
+					},
+				},
+				Service: Service{
+					Extensions: []string{"conflictingextension"},
+					Pipelines: map[string]*Pipeline{
+						"metrics": {
+							Receivers: []string{"kubeletstats"},
+						},
+					},
+				},
+			},
+			expectedErr: "conflicting values for environment variable K8S_NODE_NAME",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envVars, err := tt.config.GetAllEnvironmentVariables(logr.Discard())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.EqualError(t, tt.config.ValidateEnvironmentVariables(logr.Discard()), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, envVars)
+			assert.NoError(t, tt.config.ValidateEnvironmentVariables(logr.Discard()))
+		})
+	}
+}
+
+func TestConfig_GetAllEnvironmentVariablesConnectors(t *testing.T) {
+	connectorEnvVar := v1.EnvVar{Name: "CONNECTOR_TOKEN", Value: "secret"}
+	connectors.Register("envconnector", components.NewBuilder[any]().WithName("envconnector").
+		WithEnvVarGen(func(logr.Logger, any) ([]v1.EnvVar, error) {
+			return []v1.EnvVar{connectorEnvVar}, nil
+		}).MustBuild())
+
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": map[string]interface{}{},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"envconnector":        map[string]interface{}{},
+				"envconnector/unused": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"envconnector"},
+				},
+				"metrics": {
+					Receivers: []string{"envconnector"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+	envVars, err := c.GetAllEnvironmentVariables(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []v1.EnvVar{connectorEnvVar}, envVars)
+
+	// without any connector defined
+	c.Connectors = nil
+	envVars, err = c.GetAllEnvironmentVariables(logr.Discard())
+	require.NoError(t, err)
+	assert.Empty(t, envVars)
+}
+
+func TestConfig_ServiceExtensionConfigs(t *testing.T) {
+	c := &Config{
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check": map[string]interface{}{
+					"endpoint": "0.0.0.0:13133",
+				},
+				"pprof":  nil,
+				"zpages": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"health_check", "pprof"},
+		},
+	}
+
+	configs, err := c.ServiceExtensionConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"health_check": {"endpoint": "0.0.0.0:13133"},
+		"pprof":        nil,
+	}, configs)
+
+	c.Service.Extensions = append(c.Service.Extensions, "oauth2client")
+	_, err = c.ServiceExtensionConfigs()
+	assert.EqualError(t, err, "extension oauth2client is enabled but not defined")
+
+	configs, err = (&Config{}).ServiceExtensionConfigs()
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+}
+
+func TestConfig_RBACRulesForComponent(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"k8s_events": map[string]interface{}{},
+				"otlp":       map[string]interface{}{},
+				"kubeletstats/unused": map[string]interface{}{
+					"auth_type": "serviceAccount",
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"logs": {
+					Receivers: []string{"k8s_events", "otlp"},
+				},
+			},
+		},
+	}
+
+	rules, err := c.RBACRulesForComponent(logr.Discard(), KindReceiver, "k8s_events")
+	require.NoError(t, err)
+	require.NotEmpty(t, rules)
+	assert.Contains(t, rules[0].Resources, "pods")
+	assert.Equal(t, []string{"get", "list", "watch"}, rules[0].Verbs)
+
+	rules, err = c.RBACRulesForComponent(logr.Discard(), KindReceiver, "otlp")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = c.RBACRulesForComponent(logr.Discard(), KindReceiver, "kubeletstats/unused")
+	assert.EqualError(t, err, "receiver kubeletstats/unused is not enabled")
+}
+
+func TestConfig_ConnectorRetriever(t *testing.T) {
+	original := parserRetrievers
+	t.Cleanup(func() {
+		parserRetrievers = original
+	})
+	rule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+	parserRetrievers.Connectors = func(name string) components.Parser {
+		return components.NewSinglePortParserBuilder("fakeconnector", 9999).
+			WithRbacGen(func(logr.Logger, *components.SingleEndpointConfig) ([]rbacv1.PolicyRule, error) {
+				return []rbacv1.PolicyRule{rule}, nil
+			}).MustBuild()
+	}
+
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": map[string]interface{}{},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"fakeconnector": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"fakeconnector"},
+				},
+				"metrics": {
+					Receivers: []string{"fakeconnector"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+
+	ports, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []v1.ServicePort{{Name: "fakeconnector", Port: 9999}}, ports)
+
+	rules, err := c.GetAllRbacRules(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.PolicyRule{rule}, rules)
+
+	rules, err = c.RBACRulesForComponent(logr.Discard(), KindConnector, "fakeconnector")
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.PolicyRule{rule}, rules)
+
+	require.NoError(t, c.ApplyDefaults(logr.Discard()))
+	assert.Equal(t, map[string]interface{}{"endpoint": "0.0.0.0:9999"}, c.Connectors.Object["fakeconnector"])
+}
+
+func TestConfig_GetAllPortsPerProtocol(t *testing.T) {
+	grpcPort := v1.ServicePort{Name: "otlp-grpc", Port: 4317, TargetPort: intstr.FromInt32(4317), AppProtocol: ptr.To("grpc")}
+	httpPort := v1.ServicePort{Name: "otlp-http", Port: 4318, TargetPort: intstr.FromInt32(4318), AppProtocol: ptr.To("http")}
+	for _, tt := range []struct {
+		desc      string
+		protocols map[string]interface{}
+		expected  []v1.ServicePort
+	}{
+		{
+			desc:      "grpc only",
+			protocols: map[string]interface{}{"grpc": nil},
+			expected:  []v1.ServicePort{grpcPort},
+		},
+		{
+			desc:      "http only",
+			protocols: map[string]interface{}{"http": map[string]interface{}{}},
+			expected:  []v1.ServicePort{httpPort},
+		},
+		{
+			desc:      "both",
+			protocols: map[string]interface{}{"grpc": nil, "http": nil},
+			expected:  []v1.ServicePort{grpcPort, httpPort},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{
+					Object: map[string]interface{}{
+						"otlp": map[string]interface{}{
+							"protocols": tt.protocols,
+						},
+					},
+				},
+				Service: Service{
+					Pipelines: map[string]*Pipeline{
+						"traces": {
+							Receivers: []string{"otlp"},
+						},
+					},
+				},
+			}
+			ports, err := c.GetAllPorts(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ports)
+		})
+	}
+}
+
+func TestConfig_GetAllPortsNodePort(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{
+							"node_port": 30317,
+						},
+						"http": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+				},
+			},
+		},
+	}
+
+	ports, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, ports, 2)
+	assert.Equal(t, "otlp-grpc", ports[0].Name)
+	assert.Equal(t, int32(30317), ports[0].NodePort)
+	assert.Equal(t, "otlp-http", ports[1].Name)
+	assert.Zero(t, ports[1].NodePort, "the node port is left to the cluster when unset")
+}
+
+func TestConfig_Rendered(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{
+							"endpoint":  "0.0.0.0:4317",
+							"node_port": 30317,
+						},
+						"http": nil,
+					},
+				},
+				"zipkin": map[string]interface{}{
+					"endpoint":  "0.0.0.0:9411",
+					"node_port": 30411,
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp", "zipkin"},
+				},
+			},
+		},
+	}
+
+	rendered := c.Rendered()
+	assert.Equal(t, map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{
+				"endpoint": "0.0.0.0:4317",
+			},
+			"http": nil,
+		},
+	}, rendered.Receivers.Object["otlp"])
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "0.0.0.0:9411",
+	}, rendered.Receivers.Object["zipkin"])
+
+	// The node ports are still read from the original config.
+	ports, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, ports, 3)
+	assert.ElementsMatch(t, []int32{30317, 0, 30411}, []int32{ports[0].NodePort, ports[1].NodePort, ports[2].NodePort})
+}
+
+func TestConfig_GetAllPortsDuplicateNames(t *testing.T) {
+	original := parserRetrievers
+	t.Cleanup(func() {
+		parserRetrievers = original
+	})
+	parserRetrievers.Receivers = func(name string) components.Parser {
+		return components.NewBuilder[any]().WithName(name).
+			WithPortParser(func(_ logr.Logger, name string, _ *v1.ServicePort, _ any) ([]v1.ServicePort, error) {
+				if name == "statsd" {
+					return []v1.ServicePort{
+						{Name: "statsd", Port: 8125, Protocol: v1.ProtocolUDP},
+						{Name: "metrics", Port: 9102},
+					}, nil
+				}
+				return []v1.ServicePort{{Name: "metrics", Port: 9103}}, nil
+			}).MustBuild()
+	}
+
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"statsd": map[string]interface{}{},
+				"admin":  map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"metrics": {
+					Receivers: []string{"statsd", "admin"},
+				},
+			},
+		},
+	}
+
+	ports, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []v1.ServicePort{
+		{Name: "admin-metrics", Port: 9103},
+		{Name: "statsd", Port: 8125, Protocol: v1.ProtocolUDP},
+		{Name: "statsd-metrics", Port: 9102},
+	}, ports)
+
+	t.Run("components of the same type", func(t *testing.T) {
+		c.Receivers.Object["admin/a"] = map[string]interface{}{}
+		c.Service.Pipelines["metrics"].Receivers = []string{"statsd", "admin", "admin/a"}
+		ports, err := c.GetAllPorts(logr.Discard())
+		require.NoError(t, err)
+		assert.Equal(t, []v1.ServicePort{
+			{Name: "admin-a-metrics", Port: 9103},
+			{Name: "admin-metrics", Port: 9103},
+			{Name: "statsd", Port: 8125, Protocol: v1.ProtocolUDP},
+			{Name: "statsd-metrics", Port: 9102},
+		}, ports)
+	})
+}
+
+func TestConfig_ExtensionPortsDuplicateNames(t *testing.T) {
+	withExtensionParsers(t, map[string]components.Parser{
+		"admin": components.NewSinglePortParserBuilder("admin", 9103).MustBuild(),
+	})
+	original := parserRetrievers.Receivers
+	t.Cleanup(func() {
+		parserRetrievers.Receivers = original
+	})
+	parserRetrievers.Receivers = func(name string) components.Parser {
+		return components.NewBuilder[any]().WithName(name).
+			WithPortParser(func(_ logr.Logger, _ string, _ *v1.ServicePort, _ any) ([]v1.ServicePort, error) {
+				return []v1.ServicePort{{Name: "admin", Port: 9102}}, nil
+			}).MustBuild()
+	}
+
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"statsd": map[string]interface{}{},
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"admin": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"admin"},
+			Pipelines: map[string]*Pipeline{
+				"metrics": {
+					Receivers: []string{"statsd"},
+				},
+			},
+		},
+	}
+
+	// the extension's port is named as in all the ports, where the receiver's port shares its name
+	ports, err := c.ExtensionPorts(logr.Discard(), "admin")
+	require.NoError(t, err)
+	require.Len(t, ports, 1)
+	assert.Equal(t, "admin-admin", ports[0].Name)
+	allPorts, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Contains(t, allPorts, ports[0])
+}
+
+func TestConfig_PipelinePorts(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"zipkin":  map[string]interface{}{},
+				"filelog": map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"endpoint": "0.0.0.0:8889",
+				},
+				"otlp": map[string]interface{}{
+					"endpoint": "backend:4317",
+				},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"spanmetrics": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp", "zipkin"},
+					Exporters: []string{"otlp", "spanmetrics"},
+				},
+				"metrics": {
+					Receivers: []string{"otlp", "spanmetrics"},
+					Exporters: []string{"prometheus"},
+				},
+				"logs": {
+					Receivers: []string{"filelog"},
+					Exporters: []string{"otlp"},
+				},
+			},
+		},
+	}
+
+	ports, err := c.PipelinePorts(logr.Discard())
+	require.NoError(t, err)
+	otlpPort := v1.ServicePort{Name: "otlp-grpc", Port: 4317, TargetPort: intstr.FromInt32(4317), AppProtocol: ptr.To("grpc")}
+	assert.Equal(t, map[string][]v1.ServicePort{
+		"traces": {
+			otlpPort,
+			{Name: "zipkin", Port: 9411, TargetPort: intstr.FromInt32(9411), Protocol: v1.ProtocolTCP, AppProtocol: ptr.To("http")},
+		},
+		"metrics": {
+			otlpPort,
+			{Name: "prometheus", Port: 8889},
+		},
+		"logs": nil,
+	}, ports)
+}
+
+func TestConfig_ReceiversWithoutPorts(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"filelog": map[string]interface{}{
+					"include": []interface{}{"/var/log/pods/*/*/*.log"},
+				},
+				"hostmetrics": map[string]interface{}{},
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"zipkin": map[string]interface{}{},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": map[string]interface{}{},
+			},
+		},
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"forward": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"logs": {
+					Receivers: []string{"filelog", "otlp"},
+					Exporters: []string{"forward"},
+				},
+				"logs/2": {
+					Receivers: []string{"forward"},
+					Exporters: []string{"debug"},
+				},
+				"metrics": {
+					Receivers: []string{"hostmetrics"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+
+	receivers, err := c.ReceiversWithoutPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"filelog", "hostmetrics"}, receivers)
+}
+
+func TestConfig_PortRange(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": map[string]interface{}{},
+			},
+		},
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"health_check"},
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+
+	lowest, highest, err := c.PortRange(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4317), lowest)
+	assert.Equal(t, int32(13133), highest)
+
+	c.Service.SetMetricsAddress("0.0.0.0", 9090)
+	c.Service.Telemetry.Object["metrics"].(map[string]interface{})["address"] = "0.0.0.0:${env:METRICS_PORT}"
+	c.Service.Extensions = nil
+	lowest, highest, err = c.PortRange(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4317), lowest)
+	assert.Equal(t, int32(4317), highest)
+
+	c.Service.Pipelines = nil
+	_, _, err = c.PortRange(logr.Discard())
+	assert.EqualError(t, err, "no port could be resolved from the config")
+}
+
+func TestConfig_GetExtensionPorts(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	err = go_yaml.Unmarshal(collectorYaml, c)
+	require.NoError(t, err)
+	ports, err := c.GetExtensionPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []v1.ServicePort{
+		{
+			Name:        "health-check",
+			AppProtocol: ptr.To("http"),
+			Port:        13133,
+		},
+	}, ports)
+}
+
+func TestConfig_ExtensionPorts(t *testing.T) {
+	c := &Config{
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check":   map[string]interface{}{"endpoint": "0.0.0.0:13134"},
+				"health_check/2": map[string]interface{}{"endpoint": "0.0.0.0:13135"},
+				"pprof":          map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"pprof", "health_check"},
+		},
+	}
+
+	ports, err := c.ExtensionPorts(logr.Discard(), "health_check")
+	require.NoError(t, err)
+	assert.Equal(t, []v1.ServicePort{
+		{
+			Name:        "health-check",
+			AppProtocol: ptr.To("http"),
+			Port:        13134,
+		},
+	}, ports)
+
+	ports, err = c.ExtensionPorts(logr.Discard(), "zpages")
+	require.NoError(t, err)
+	assert.Empty(t, ports)
+}
+
+func TestConfigFromMap(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+	collectorJson, err := yaml.YAMLToJSON(collectorYaml)
+	require.NoError(t, err)
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(collectorJson, &m))
+
+	cfg, err := ConfigFromMap(m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pprof", "zpages", "health_check"}, cfg.Service.Extensions)
+	assert.Equal(t, []string{"otlp"}, cfg.Service.Pipelines["traces"].Receivers)
+	assert.Contains(t, cfg.Exporters.Object, "zipkin")
+
+	jsonCfg, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(collectorJson), string(jsonCfg))
+}
+
+func TestConfigFromMapInvalid(t *testing.T) {
+	_, err := ConfigFromMap(map[string]interface{}{
+		"service": map[string]interface{}{
+			"pipelines": "traces",
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestConfig_OrderedServiceExtensions(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Extensions: []string{"zpages", "health_check", "pprof", "health_check"},
+		},
+	}
+	assert.Equal(t, []string{"zpages", "health_check", "pprof"}, c.OrderedServiceExtensions())
+	assert.Empty(t, (&Config{}).OrderedServiceExtensions())
+}
+
+func TestConfig_GetLivenessProbeDeclaredOrder(t *testing.T) {
+	for _, name := range []string{"probeextensiona", "probeextensionb"} {
+		path := "/" + name
+		extensions.Register(name, components.NewBuilder[any]().WithName(name).
+			WithLivenessGen(func(logr.Logger, any) (*v1.Probe, error) {
+				return &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: path}}}, nil
+			}).MustBuild())
+	}
+
+	for _, order := range [][]string{
+		{"probeextensiona", "probeextensionb"},
+		{"probeextensionb", "probeextensiona"},
+	} {
+		c := &Config{
+			Extensions: &AnyConfig{
+				Object: map[string]interface{}{
+					"probeextensiona": map[string]interface{}{},
+					"probeextensionb": map[string]interface{}{},
+				},
+			},
+			Service: Service{
+				Extensions: order,
+			},
+		}
+		probe, err := c.GetLivenessProbe(logr.Discard())
+		require.NoError(t, err)
+		require.NotNil(t, probe)
+		assert.Equal(t, "/"+order[0], probe.HTTPGet.Path)
+	}
+}
+
+func TestConfig_GetContainerPorts(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	servicePorts, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	containerPorts, err := c.GetContainerPorts(logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, containerPorts, len(servicePorts))
+
+	for i, servicePort := range servicePorts {
+		containerPort := containerPorts[i]
+		assert.Equal(t, servicePort.Name, containerPort.Name)
+		assert.Equal(t, servicePort.Protocol, containerPort.Protocol)
+		if servicePort.TargetPort.IntValue() > 0 {
+			assert.Equal(t, servicePort.TargetPort.IntVal, containerPort.ContainerPort)
+		} else {
+			assert.Equal(t, servicePort.Port, containerPort.ContainerPort)
+		}
+	}
+}
+
+func TestConfig_GetContainerPortsDedup(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"otlp/2": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp", "otlp/2"},
+				},
+			},
+		},
+	}
+
+	servicePorts, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, servicePorts, 2)
+
+	containerPorts, err := c.GetContainerPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []v1.ContainerPort{
+		{Name: servicePorts[0].Name, ContainerPort: 4317, Protocol: servicePorts[0].Protocol},
+	}, containerPorts)
+
+	t.Run("same number with different protocols", func(t *testing.T) {
+		original := parserRetrievers
+		t.Cleanup(func() {
+			parserRetrievers = original
+		})
+		parserRetrievers.Receivers = func(name string) components.Parser {
+			return components.NewBuilder[any]().WithName(name).
+				WithPortParser(func(_ logr.Logger, _ string, _ *v1.ServicePort, _ any) ([]v1.ServicePort, error) {
+					return []v1.ServicePort{
+						{Name: "syslog-tcp", Port: 514, Protocol: v1.ProtocolTCP},
+						{Name: "syslog-udp", Port: 514, Protocol: v1.ProtocolUDP},
+					}, nil
+				}).MustBuild()
+		}
+		c := &Config{
+			Receivers: AnyConfig{
+				Object: map[string]interface{}{
+					"syslog": map[string]interface{}{},
+				},
+			},
+			Service: Service{
+				Pipelines: map[string]*Pipeline{
+					"logs": {
+						Receivers: []string{"syslog"},
+					},
+				},
+			},
+		}
+		containerPorts, err := c.GetContainerPorts(logr.Discard())
+		require.NoError(t, err)
+		assert.Equal(t, []v1.ContainerPort{
+			{Name: "syslog-tcp", ContainerPort: 514, Protocol: v1.ProtocolTCP},
+			{Name: "syslog-udp", ContainerPort: 514, Protocol: v1.ProtocolUDP},
+		}, containerPorts)
+	})
+}
+
+func TestConfig_Ports(t *testing.T) {
+	collectorYaml, err := os.ReadFile("testdata/otelcol-demo.yaml")
+	require.NoError(t, err)
+
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal(collectorYaml, c))
+
+	servicePorts, containerPorts, err := c.Ports(logr.Discard())
+	require.NoError(t, err)
+	require.NotEmpty(t, servicePorts)
+
+	allPorts, err := c.GetAllPorts(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, allPorts, servicePorts)
+
+	byName := map[string]v1.ContainerPort{}
+	byNumber := map[string]v1.ContainerPort{}
+	for _, containerPort := range containerPorts {
+		byName[containerPort.Name] = containerPort
+		byNumber[portKey(containerPort.ContainerPort, containerPort.Protocol)] = containerPort
+	}
+	require.Len(t, byNumber, len(containerPorts), "container ports must have distinct numbers and protocols")
+	for _, servicePort := range servicePorts {
+		target := servicePort.Port
+		if servicePort.TargetPort.IntValue() > 0 {
+			target = servicePort.TargetPort.IntVal
+		}
+		containerPort, ok := byNumber[portKey(target, servicePort.Protocol)]
+		require.True(t, ok, "no container port backs service port %s", servicePort.Name)
+		assert.Equal(t, servicePort.Protocol, containerPort.Protocol)
+		if owner, ok := byName[servicePort.Name]; ok {
+			assert.Equal(t, target, owner.ContainerPort)
+		}
+	}
+}
+
+func TestConfig_ComponentDefaults(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+						"http": map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+	before := c.Clone()
+
+	defaults, err := c.ComponentDefaults(logr.Discard(), KindReceiver, "otlp")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"protocols": map[string]interface{}{
+			"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			"http": map[string]interface{}{"endpoint": "0.0.0.0:4318"},
+		},
+	}, defaults)
+	assert.Equal(t, before, c, "the config must not be defaulted")
+
+	_, err = c.ComponentDefaults(logr.Discard(), KindReceiver, "zipkin")
+	assert.EqualError(t, err, "receiver zipkin not found")
+}
@@ -0,0 +1,851 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// Validate checks the config for errors that would prevent the collector from starting. All the problems found are
+// reported together.
+func (c *Config) Validate() error {
+	logger := logr.Discard()
+	var errs []error
+	if conflicts, err := c.portConflicts(logger); err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = append(errs, conflicts...)
+	}
+	if err := c.validateTelemetryMetricsExposition(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateTelemetryLevel(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateNoDuplicateTelemetryAddress(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateConnectorSignalCompatibility(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.ValidatePipelineProcessorUniqueness(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateExtensionProbeSingleton(logger); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateExtensionDeps(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateEnvironmentVariables(logger); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateIDs(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateReceiverCreatorScope(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ValidateComponentConfigs(logger); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Warnings returns the problems found in the config that don't prevent the collector from starting, but are likely
+// mistakes.
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if orphans := c.OrphanConnectors(); len(orphans) > 0 {
+		warnings = append(warnings, fmt.Sprintf("connectors defined but not used by any pipeline: %s", strings.Join(orphans, ", ")))
+	}
+	warnings = append(warnings, c.ProcessorOrderWarnings()...)
+	duplicateProcessors, _ := c.ValidatePipelineProcessorUniqueness()
+	warnings = append(warnings, duplicateProcessors...)
+	for _, component := range c.EmptyComponentsWithRequiredFields() {
+		warnings = append(warnings, fmt.Sprintf("%s has no configuration but requires: %s", component.Path, strings.Join(component.RequiredFields, ", ")))
+	}
+	if pipelines := c.MissingBatchProcessorPipelines(); len(pipelines) > 0 {
+		warnings = append(warnings, fmt.Sprintf("pipelines exporting data without a batch processor: %s", strings.Join(pipelines, ", ")))
+	}
+	if pipelines := c.PipelinesWithoutRealExporter(); len(pipelines) > 0 {
+		warnings = append(warnings, fmt.Sprintf("pipelines not exporting data to any backend: %s", strings.Join(pipelines, ", ")))
+	}
+	return warnings
+}
+
+// firstProcessorTypes holds the types of the processors recommended to come first in a pipeline.
+var firstProcessorTypes = []string{"memory_limiter"}
+
+// processorOrderRecommendations holds pairs of processor types, the first of which is recommended to come before the
+// second in a pipeline.
+var processorOrderRecommendations = [][2]string{
+	{"memory_limiter", "batch"},
+}
+
+// ProcessorOrderWarnings returns warnings for the pipelines whose processors aren't in the recommended order, such as a
+// memory_limiter processor that isn't first, or a batch processor before the memory_limiter one.
+func (c *Config) ProcessorOrderWarnings() []string {
+	var warnings []string
+	for _, name := range c.pipelineNames() {
+		processors := c.Service.Pipelines[name].Processors
+		firstIndex := map[string]int{}
+		for i, id := range processors {
+			if _, ok := firstIndex[components.ComponentType(id)]; !ok {
+				firstIndex[components.ComponentType(id)] = i
+			}
+		}
+		for i, id := range processors {
+			if i > 0 && slices.Contains(firstProcessorTypes, components.ComponentType(id)) {
+				warnings = append(warnings, fmt.Sprintf("pipeline %s: processor %s should be the first processor", name, id))
+			}
+		}
+		for _, recommendation := range processorOrderRecommendations {
+			before, beforeOk := firstIndex[recommendation[0]]
+			after, afterOk := firstIndex[recommendation[1]]
+			if beforeOk && afterOk && after < before {
+				warnings = append(warnings, fmt.Sprintf("pipeline %s: %s processors should come after %s processors", name, recommendation[1], recommendation[0]))
+			}
+		}
+	}
+	return warnings
+}
+
+// EmptyComponent is a component defined without configuration whose parser requires some fields.
+// +kubebuilder:object:generate=false
+type EmptyComponent struct {
+	// Path is the component's section and ID, such as "exporters.otlp".
+	Path string
+	// RequiredFields are the dotted paths of the fields the component's parser requires.
+	RequiredFields []string
+}
+
+// EmptyComponentsWithRequiredFields returns the components defined without configuration, such as a bare "otlp:"
+// exporter, even though their parser requires some fields, sorted by path. Such a component runs with the defaults,
+// which is sometimes intended but often means the configuration was forgotten. Unlike MissingRequiredFields, the
+// components with some configuration aren't reported.
+func (c *Config) EmptyComponentsWithRequiredFields() []EmptyComponent {
+	var empty []EmptyComponent
+	for _, kind := range AllComponentKinds() {
+		retriever := parserRetrievers.For(kind)
+		for id, cfg := range c.ComponentsOfKind(kind) {
+			if len(cfg) > 0 {
+				continue
+			}
+			if required := retriever(id).RequiredFields(); len(required) > 0 {
+				empty = append(empty, EmptyComponent{Path: kind.String() + "s." + id, RequiredFields: required})
+			}
+		}
+	}
+	sort.Slice(empty, func(i, j int) bool {
+		return empty[i].Path < empty[j].Path
+	})
+	return empty
+}
+
+// nonBackendExporterTypes holds the types of the exporters that don't send data to a backend.
+var nonBackendExporterTypes = []string{"debug", "logging", "nop"}
+
+// PipelinesWithoutRealExporter returns the names of the pipelines whose exporters are all exporters that don't send data
+// to a backend, such as the debug one, sorted. These are often placeholders forgotten in a production config. The
+// pipelines exporting to a connector are left out, as their data may reach a backend through another pipeline, as are
+// the pipelines without exporters, which Service.Validate rejects.
+func (c *Config) PipelinesWithoutRealExporter() []string {
+	connectors := c.ComponentsOfKind(KindConnector)
+	var pipelines []string
+	for _, name := range c.pipelineNames() {
+		exporters := c.Service.Pipelines[name].Exporters
+		if len(exporters) == 0 {
+			continue
+		}
+		if !slices.ContainsFunc(exporters, func(id string) bool {
+			_, isConnector := connectors[id]
+			return isConnector || !slices.Contains(nonBackendExporterTypes, components.ComponentType(id))
+		}) {
+			pipelines = append(pipelines, name)
+		}
+	}
+	return pipelines
+}
+
+// MissingBatchProcessorPipelines returns the names of the pipelines that have exporters but no batch processor, which
+// usually hurts throughput, sorted. The pipelines only exporting to connectors are left out, as their data doesn't leave
+// the collector.
+func (c *Config) MissingBatchProcessorPipelines() []string {
+	connectors := c.ComponentsOfKind(KindConnector)
+	var missing []string
+	for _, name := range c.pipelineNames() {
+		pipeline := c.Service.Pipelines[name]
+		if !slices.ContainsFunc(pipeline.Exporters, func(id string) bool {
+			_, isConnector := connectors[id]
+			return !isConnector
+		}) {
+			continue
+		}
+		if !slices.ContainsFunc(pipeline.Processors, func(id string) bool {
+			return components.ComponentType(id) == "batch"
+		}) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// ValidatePipelineProcessorUniqueness checks that no pipeline lists a component twice. A processor listed twice
+// processes the data twice, which is almost always a mistake, and is returned as a warning, while a receiver or an
+// exporter listed twice is rejected by the collector and is returned as an error. All the duplicates are reported.
+func (c *Config) ValidatePipelineProcessorUniqueness() ([]string, error) {
+	var warnings []string
+	var errs []error
+	for _, name := range c.pipelineNames() {
+		pipeline := c.Service.Pipelines[name]
+		for _, kind := range []ComponentKind{KindReceiver, KindProcessor, KindExporter} {
+			for _, id := range duplicateIDs(pipeline.componentIDs(kind)) {
+				if kind == KindProcessor {
+					warnings = append(warnings, fmt.Sprintf("pipeline %s: processor %s is listed more than once", name, id))
+				} else {
+					errs = append(errs, fmt.Errorf("pipeline %s: %s %s is listed more than once", name, kind, id))
+				}
+			}
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// duplicateIDs returns the IDs found more than once in the given list, in the order of their first duplicate.
+func duplicateIDs(ids []string) []string {
+	// seen holds whether each ID found so far was already reported as a duplicate.
+	seen := map[string]bool{}
+	var duplicates []string
+	for _, id := range ids {
+		reported, ok := seen[id]
+		if ok && !reported {
+			duplicates = append(duplicates, id)
+		}
+		seen[id] = ok
+	}
+	return duplicates
+}
+
+// ValidateExtensionProbeSingleton checks that at most one enabled extension provides the collector's liveness probe,
+// and at most one its readiness probe, as the collector's container can only have one of each.
+func (c *Config) ValidateExtensionProbeSingleton(logger logr.Logger) error {
+	var livenessProviders, readinessProviders []string
+	for _, id := range c.OrderedServiceExtensions() {
+		parser := parserRetrievers.Extensions(id)
+		cfg := c.componentConfigs(KindExtension)[id]
+		if probe, err := parser.GetLivenessProbe(logger, cfg); err != nil {
+			return err
+		} else if probe != nil {
+			livenessProviders = append(livenessProviders, id)
+		}
+		if probe, err := parser.GetReadinessProbe(logger, cfg); err != nil {
+			return err
+		} else if probe != nil {
+			readinessProviders = append(readinessProviders, id)
+		}
+	}
+	var errs []error
+	if len(livenessProviders) > 1 {
+		errs = append(errs, fmt.Errorf("only one extension may provide the liveness probe, found: %s", strings.Join(livenessProviders, ", ")))
+	}
+	if len(readinessProviders) > 1 {
+		errs = append(errs, fmt.Errorf("only one extension may provide the readiness probe, found: %s", strings.Join(readinessProviders, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateEnvironmentVariables checks that the enabled components don't require different values for the same
+// environment variable, as the collector's container can only have one.
+func (c *Config) ValidateEnvironmentVariables(logger logr.Logger) error {
+	_, err := c.GetAllEnvironmentVariables(logger)
+	return err
+}
+
+// envVarConflictsError returns an error reporting each of the given environment variables required with different
+// values, if any.
+func envVarConflictsError(conflicts []string) error {
+	var errs []error
+	for _, name := range conflicts {
+		errs = append(errs, fmt.Errorf("conflicting values for environment variable %s", name))
+	}
+	return errors.Join(errs...)
+}
+
+// extensionDependencyFields holds, by extension type, the fields of the extensions' configuration naming another
+// extension they depend on, such as the storage extension backing the ack extension.
+var extensionDependencyFields = map[string][]string{
+	"ack": {"storage"},
+}
+
+// ValidateExtensionDeps checks that the extensions the enabled extensions depend on, as named by the fields listed in
+// extensionDependencyFields, are enabled too, as the collector fails to start the dependent extensions otherwise.
+func (c *Config) ValidateExtensionDeps() error {
+	enabled := c.OrderedServiceExtensions()
+	var errs []error
+	for _, id := range enabled {
+		cfg, ok := c.componentConfigs(KindExtension)[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range extensionDependencyFields[components.ComponentType(id)] {
+			dependency, ok := cfg[field].(string)
+			if !ok || dependency == "" || slices.Contains(enabled, dependency) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("extension %s depends on extension %s, set in %s, which isn't enabled", id, dependency, field))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// componentTypeRegex matches the valid types of the component IDs and pipeline names, as the collector defines them.
+var componentTypeRegex = regexp.MustCompile(`^[a-zA-Z][0-9a-zA-Z_]{0,62}$`)
+
+// componentNameRegex matches the valid names of the component IDs and pipeline names, as the collector defines them:
+// anything but whitespace, control characters and symbols.
+var componentNameRegex = regexp.MustCompile(`^[^\pZ\pC\pS]+$`)
+
+// componentNameMaxLength is the maximum length, in bytes, of the names of the component IDs and pipeline names, as the
+// collector defines it.
+const componentNameMaxLength = 1024
+
+// ValidateIDs checks that the IDs of the defined components and the names of the pipelines are made of a valid type,
+// optionally followed by a slash and a non-empty name, as the collector requires, which it otherwise fails on with
+// cryptic errors. All the invalid IDs are reported.
+func (c *Config) ValidateIDs() error {
+	var errs []error
+	for _, kind := range AllComponentKinds() {
+		for _, id := range slices.Sorted(maps.Keys(c.ComponentsOfKind(kind))) {
+			if err := validateID(id); err != nil {
+				errs = append(errs, fmt.Errorf("%s ID %q is invalid: %w", kind, id, err))
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Service.Pipelines)) {
+		if err := validateID(name); err != nil {
+			errs = append(errs, fmt.Errorf("pipeline name %q is invalid: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateID checks that the given component ID or pipeline name is a valid type, optionally followed by a slash and
+// a valid name.
+func validateID(id string) error {
+	idType, name, hasName := strings.Cut(id, "/")
+	if !componentTypeRegex.MatchString(idType) {
+		return fmt.Errorf("the type %q must match %s", idType, componentTypeRegex)
+	}
+	if hasName && (len(name) > componentNameMaxLength || !componentNameRegex.MatchString(name)) {
+		return fmt.Errorf("the name %q must be 1 to %d characters long, excluding whitespace, control characters and symbols", name, componentNameMaxLength)
+	}
+	return nil
+}
+
+// observerTypeSuffix ends the types of the observer extensions, such as k8s_observer or host_observer.
+const observerTypeSuffix = "_observer"
+
+// ValidateReceiverCreatorScope checks that each enabled receiver_creator receiver has an observer extension to discover
+// its targets: the observers it watches must be enabled in the service or, if it doesn't list them, at least one
+// observer extension must be. All the problems found are reported together.
+func (c *Config) ValidateReceiverCreatorScope() error {
+	enabledObservers := map[string]struct{}{}
+	for _, id := range c.OrderedServiceExtensions() {
+		if strings.HasSuffix(components.ComponentType(id), observerTypeSuffix) {
+			enabledObservers[id] = struct{}{}
+		}
+	}
+	receivers := c.ComponentsOfKind(KindReceiver)
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(c.GetEnabledComponents()[KindReceiver])) {
+		if components.ComponentType(id) != "receiver_creator" {
+			continue
+		}
+		watched, _ := receivers[id]["watch_observers"].([]interface{})
+		if len(watched) == 0 {
+			if len(enabledObservers) == 0 {
+				errs = append(errs, fmt.Errorf("receiver %s requires an observer extension, such as k8s_observer, to be enabled", id))
+			}
+			continue
+		}
+		for _, observer := range watched {
+			if _, ok := enabledObservers[fmt.Sprint(observer)]; !ok {
+				errs = append(errs, fmt.Errorf("receiver %s watches the observer %v, which isn't an enabled observer extension", id, observer))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OrphanConnectors returns the IDs of the connectors defined in the config that no pipeline uses, neither as an
+// exporter nor as a receiver, sorted.
+func (c *Config) OrphanConnectors() []string {
+	usage := c.ComponentUsage()
+	var orphans []string
+	for id := range c.ComponentsOfKind(KindConnector) {
+		if usage[componentUsageKey(KindConnector, id)] == 0 {
+			orphans = append(orphans, id)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// connectorSignals holds, by connector type, the signal types of the pipelines each type of connector can be used as an
+// exporter in, mapped to the signal types of the pipelines it can then be used as a receiver in.
+var connectorSignals = map[string]map[string][]string{
+	"count": {
+		PipelineTypeTraces:  {PipelineTypeMetrics},
+		PipelineTypeMetrics: {PipelineTypeMetrics},
+		PipelineTypeLogs:    {PipelineTypeMetrics},
+	},
+	"exceptions": {
+		PipelineTypeTraces: {PipelineTypeMetrics, PipelineTypeLogs},
+	},
+	"failover": {
+		PipelineTypeTraces:  {PipelineTypeTraces},
+		PipelineTypeMetrics: {PipelineTypeMetrics},
+		PipelineTypeLogs:    {PipelineTypeLogs},
+	},
+	"forward": {
+		PipelineTypeTraces:  {PipelineTypeTraces},
+		PipelineTypeMetrics: {PipelineTypeMetrics},
+		PipelineTypeLogs:    {PipelineTypeLogs},
+	},
+	"roundrobin": {
+		PipelineTypeTraces:  {PipelineTypeTraces},
+		PipelineTypeMetrics: {PipelineTypeMetrics},
+		PipelineTypeLogs:    {PipelineTypeLogs},
+	},
+	"routing": {
+		PipelineTypeTraces:  {PipelineTypeTraces},
+		PipelineTypeMetrics: {PipelineTypeMetrics},
+		PipelineTypeLogs:    {PipelineTypeLogs},
+	},
+	"servicegraph": {
+		PipelineTypeTraces: {PipelineTypeMetrics},
+	},
+	"spanmetrics": {
+		PipelineTypeTraces: {PipelineTypeMetrics},
+	},
+}
+
+// ValidateConnectorSignalCompatibility checks that the connectors of a known type are only used between pipelines of
+// signal types they support, such as a spanmetrics connector exporting from traces pipelines to metrics ones. Connectors
+// of an unknown type and pipelines of an invalid signal type aren't checked. All the problems found are reported
+// together.
+func (c *Config) ValidateConnectorSignalCompatibility() error {
+	connectors := c.ComponentsOfKind(KindConnector)
+	exportingPipelines := map[string][]string{}
+	receivingPipelines := map[string][]string{}
+	for _, name := range c.pipelineNames() {
+		if !ValidPipelineType(components.ComponentType(name)) {
+			continue
+		}
+		pipeline := c.Service.Pipelines[name]
+		for _, id := range pipeline.Exporters {
+			if _, ok := connectors[id]; ok && !slices.Contains(exportingPipelines[id], name) {
+				exportingPipelines[id] = append(exportingPipelines[id], name)
+			}
+		}
+		for _, id := range pipeline.Receivers {
+			if _, ok := connectors[id]; ok && !slices.Contains(receivingPipelines[id], name) {
+				receivingPipelines[id] = append(receivingPipelines[id], name)
+			}
+		}
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(connectors)) {
+		signals, ok := connectorSignals[components.ComponentType(id)]
+		if !ok {
+			continue
+		}
+		emitted := map[string]struct{}{}
+		for _, outputs := range signals {
+			for _, output := range outputs {
+				emitted[output] = struct{}{}
+			}
+		}
+		for _, from := range exportingPipelines[id] {
+			fromType := components.ComponentType(from)
+			outputs, ok := signals[fromType]
+			if !ok {
+				errs = append(errs, fmt.Errorf("connector %s can't be used as an exporter in pipeline %s, it only accepts %s", id, from, strings.Join(slices.Sorted(maps.Keys(signals)), ", ")))
+				continue
+			}
+			for _, to := range receivingPipelines[id] {
+				toType := components.ComponentType(to)
+				if _, ok := emitted[toType]; ok && !slices.Contains(outputs, toType) {
+					errs = append(errs, fmt.Errorf("connector %s can't connect pipeline %s to pipeline %s, it only turns %s into %s", id, from, to, fromType, strings.Join(outputs, ", ")))
+				}
+			}
+		}
+		for _, to := range receivingPipelines[id] {
+			if _, ok := emitted[components.ComponentType(to)]; !ok {
+				errs = append(errs, fmt.Errorf("connector %s can't be used as a receiver in pipeline %s, it only emits %s", id, to, strings.Join(slices.Sorted(maps.Keys(emitted)), ", ")))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Profiles of the environments a collector can be deployed to, as accepted by ValidateForProfile.
+const (
+	// ProfileDefault puts no restriction on the components.
+	ProfileDefault = "default"
+	// ProfileServerless is for serverless targets, such as Knative, where the collector has no access to the host nor
+	// to persistent storage, which rules out the components relying on them.
+	ProfileServerless = "serverless"
+)
+
+// profiles lists the profiles accepted by ValidateForProfile.
+var profiles = []string{ProfileDefault, ProfileServerless}
+
+// profileIncompatibleComponentTypes holds, by profile, the types of components, by kind, that can't run in the profile's
+// environment.
+var profileIncompatibleComponentTypes = map[string]map[ComponentKind][]string{
+	ProfileServerless: {
+		KindReceiver:  {"filelog", "hostmetrics", "journald"},
+		KindExtension: {"file_storage"},
+	},
+}
+
+// ValidateForProfile checks that the enabled components can run in the environment described by the given profile, one
+// of ProfileDefault and ProfileServerless, such as the serverless profile rejecting the hostmetrics receiver. Every
+// incompatible component is reported.
+func (c *Config) ValidateForProfile(profile string) error {
+	if !slices.Contains(profiles, profile) {
+		return fmt.Errorf("unknown profile %q, must be one of: %s", profile, strings.Join(profiles, ", "))
+	}
+	incompatible := profileIncompatibleComponentTypes[profile]
+	enabled := c.GetEnabledComponents()
+	var errs []error
+	for _, kind := range AllComponentKinds() {
+		for _, id := range slices.Sorted(maps.Keys(enabled[kind])) {
+			if slices.Contains(incompatible[kind], components.ComponentType(id)) {
+				errs = append(errs, fmt.Errorf("%s %s can't be used with the %s profile", kind, id, profile))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// schemeRequiredExporterTypes holds the types of the exporters whose endpoint must be a URL with a scheme.
+var schemeRequiredExporterTypes = []string{"otlphttp", "prometheusremotewrite", "zipkin"}
+
+// ValidateExporterEndpoints returns warnings for the enabled exporters whose endpoint is obviously malformed: neither a
+// URL nor a host:port, or not a URL for the exporters requiring a scheme, such as otlphttp. Endpoints set from
+// environment variables are only known at runtime and aren't checked.
+func (c *Config) ValidateExporterEndpoints(logger logr.Logger) []string {
+	endpoints, err := c.ExporterEndpoints(logger)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var warnings []string
+	for _, id := range slices.Sorted(maps.Keys(endpoints)) {
+		endpoint := endpoints[id]
+		if strings.Contains(endpoint, "${") {
+			continue
+		}
+		if strings.Contains(endpoint, "://") {
+			if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				warnings = append(warnings, fmt.Sprintf("exporter %s endpoint %q is not a valid URL", id, endpoint))
+			}
+			continue
+		}
+		if slices.Contains(schemeRequiredExporterTypes, components.ComponentType(id)) {
+			warnings = append(warnings, fmt.Sprintf("exporter %s endpoint %q must be a URL with a scheme, such as https://%s", id, endpoint, endpoint))
+			continue
+		}
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			warnings = append(warnings, fmt.Sprintf("exporter %s endpoint %q is neither a URL nor a host:port", id, endpoint))
+		}
+	}
+	return warnings
+}
+
+// ValidatePorts checks that no two ports of the collector, those of the enabled components and the one it exposes its
+// own metrics on, share a number and protocol or a name, which would make the collector fail at startup or the service
+// be rejected. Every conflict found is reported. Ports only known at runtime, such as those set from environment
+// variables, aren't checked.
+func (c *Config) ValidatePorts(logger logr.Logger) error {
+	conflicts, err := c.portConflicts(logger)
+	if err != nil {
+		return err
+	}
+	return errors.Join(conflicts...)
+}
+
+// portConflicts returns an error for each conflict between the ports of the collector, as described by ValidatePorts.
+// The returned error is set if the ports can't be resolved.
+func (c *Config) portConflicts(logger logr.Logger) ([]error, error) {
+	ports, err := c.GetAllPorts(logger)
+	if err != nil {
+		return nil, err
+	}
+	namesByNumber, nameCounts := groupPorts(ports)
+
+	var conflicts []error
+	if err := c.telemetryPortConflict(logger, namesByNumber); err != nil {
+		conflicts = append(conflicts, err)
+	}
+	for _, key := range slices.Sorted(maps.Keys(namesByNumber)) {
+		if names := namesByNumber[key]; len(names) > 1 {
+			conflicts = append(conflicts, fmt.Errorf("port %s is used by several ports: %s", key, strings.Join(names, ", ")))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(nameCounts)) {
+		if nameCounts[name] > 1 {
+			conflicts = append(conflicts, fmt.Errorf("port name %s is used by %d ports", name, nameCounts[name]))
+		}
+	}
+	return conflicts, nil
+}
+
+// validateTelemetryPort checks that the port the collector exposes its own metrics on isn't also bound by one of its
+// components, which always prevents the collector from starting. ValidatePorts reports the other port conflicts too.
+func (c *Config) validateTelemetryPort(logger logr.Logger) error {
+	ports, err := c.GetAllPorts(logger)
+	if err != nil {
+		return err
+	}
+	namesByNumber, _ := groupPorts(ports)
+	return c.telemetryPortConflict(logger, namesByNumber)
+}
+
+// groupPorts returns the names of the given ports keyed by portKey, and the number of ports having each name. The
+// number a port listens on is its target port if set. Ports without a number, only known at runtime, are skipped.
+func groupPorts(ports []corev1.ServicePort) (map[string][]string, map[string]int) {
+	namesByNumber := map[string][]string{}
+	nameCounts := map[string]int{}
+	for _, port := range ports {
+		number := port.Port
+		if port.TargetPort.IntValue() > 0 {
+			number = port.TargetPort.IntVal
+		}
+		if number <= 0 {
+			continue
+		}
+		key := portKey(number, port.Protocol)
+		namesByNumber[key] = append(namesByNumber[key], port.Name)
+		nameCounts[port.Name]++
+	}
+	return namesByNumber, nameCounts
+}
+
+// telemetryPortConflict returns an error if the telemetry metrics port is used by one of the given ports, keyed by
+// portKey.
+func (c *Config) telemetryPortConflict(logger logr.Logger, namesByNumber map[string][]string) error {
+	if !c.Service.MetricsEnabled() {
+		// The collector doesn't bind its metrics port.
+		return nil
+	}
+	telemetryPort, err := c.Service.MetricsPort(logger)
+	if err != nil {
+		// The port is only known at runtime, there's nothing to compare against.
+		return nil
+	}
+	if names := namesByNumber[portKey(telemetryPort, corev1.ProtocolTCP)]; len(names) > 0 {
+		return fmt.Errorf("the telemetry metrics port %d conflicts with the %s port, set service.telemetry.metrics.address to use another port", telemetryPort, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// portKey identifies a port by its number and protocol, which defaults to TCP.
+func portKey(number int32, protocol corev1.Protocol) string {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	return fmt.Sprintf("%d/%s", number, protocol)
+}
+
+// validateTelemetryMetricsExposition checks that the collector's own metrics are exposed using either the legacy
+// address or the readers, as newer collectors reject configs setting both.
+func (c *Config) validateTelemetryMetricsExposition() error {
+	if c.Service.Telemetry == nil {
+		return nil
+	}
+	metrics, ok := c.Service.Telemetry.Object["metrics"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	_, hasAddress := metrics["address"]
+	_, hasReaders := metrics["readers"]
+	if hasAddress && hasReaders {
+		return errors.New("service.telemetry.metrics sets both address and readers, use readers only as address is deprecated")
+	}
+	return nil
+}
+
+// ValidateNoDuplicateTelemetryAddress checks that the address set in service.telemetry.metrics.address isn't also the
+// address of a prometheus reader of the telemetry metrics, nor the endpoint a receiver explicitly listens on, as the
+// collector couldn't bind both. A wildcard or empty host overlaps with any host on the same port. Addresses whose port
+// is an env var can't be compared and are ignored. Only an address set by the user is compared, as ApplyDefaults
+// doesn't set one along with readers.
+func (c *Config) ValidateNoDuplicateTelemetryAddress() error {
+	if c.Service.Telemetry == nil {
+		return nil
+	}
+	metrics, ok := c.Service.Telemetry.Object["metrics"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	address, ok := metrics["address"].(string)
+	if !ok {
+		return nil
+	}
+	host, port, ok := comparableEndpoint(address)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	duplicates := func(other string) bool {
+		otherHost, otherPort, ok := comparableEndpoint(other)
+		return ok && otherPort == port && (otherHost == host || isWildcardHost(host) || isWildcardHost(otherHost))
+	}
+	readers, _ := metrics["readers"].([]interface{})
+	for i, reader := range readers {
+		readerAddress, ok := prometheusReaderAddress(reader)
+		if ok && duplicates(readerAddress) {
+			errs = append(errs, fmt.Errorf("service.telemetry.metrics.address %s duplicates the address of service.telemetry.metrics.readers[%d]", address, i))
+		}
+	}
+	receivers := c.componentConfigs(KindReceiver)
+	for _, id := range slices.Sorted(maps.Keys(receivers)) {
+		cfg, ok := receivers[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if endpoint, ok := cfg["endpoint"].(string); ok && duplicates(endpoint) {
+			errs = append(errs, fmt.Errorf("service.telemetry.metrics.address %s duplicates receivers.%s.endpoint", address, id))
+		}
+		protocols, _ := cfg["protocols"].(map[string]interface{})
+		for _, protocol := range slices.Sorted(maps.Keys(protocols)) {
+			protocolCfg, ok := protocols[protocol].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if endpoint, ok := protocolCfg["endpoint"].(string); ok && duplicates(endpoint) {
+				errs = append(errs, fmt.Errorf("service.telemetry.metrics.address %s duplicates receivers.%s.protocols.%s.endpoint", address, id, protocol))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// comparableEndpoint returns the host and port of the given address, and whether its port is known.
+func comparableEndpoint(address string) (string, int32, bool) {
+	host, port, portIsEnv, err := components.SplitEndpoint(address)
+	if err != nil || portIsEnv || port == components.UnsetPort {
+		return "", 0, false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), port, true
+}
+
+// isWildcardHost reports whether the given host binds every interface.
+func isWildcardHost(host string) bool {
+	return host == "" || host == "0.0.0.0" || host == "::"
+}
+
+// ValidateComponentConfigs checks the configuration of every defined component with its parser, such as an OTLP
+// receiver with an empty protocols block, which would prevent the collector from starting. All the problems found are
+// reported together.
+func (c *Config) ValidateComponentConfigs(logger logr.Logger) error {
+	var errs []error
+	for _, kind := range AllComponentKinds() {
+		retriever := parserRetrievers.For(kind)
+		cfg := c.componentConfigs(kind)
+		for _, id := range slices.Sorted(maps.Keys(cfg)) {
+			if err := retriever(id).ValidateConfig(logger, cfg[id]); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s %s: %w", kind, id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateTelemetryLevel checks that the telemetry metrics level, when set, is one the collector accepts. An unset level
+// is valid, the collector falls back to its default.
+func (c *Config) ValidateTelemetryLevel() error {
+	_, err := c.Service.MetricsLevel()
+	return err
+}
+
+// Validate checks the invariants of the service block on its own: there must be at least one pipeline, each pipeline
+// must have receivers and exporters, the telemetry metrics level must be valid and every enabled extension must be
+// defined in the given config. All the problems found are reported together.
+func (s *Service) Validate(defined Config) error {
+	var errs []error
+	if len(s.Pipelines) == 0 {
+		errs = append(errs, errors.New("service.pipelines must define at least one pipeline"))
+	}
+	names := make([]string, 0, len(s.Pipelines))
+	for name := range s.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pipeline := s.Pipelines[name]
+		if pipeline == nil || len(pipeline.Receivers) == 0 {
+			errs = append(errs, fmt.Errorf("pipeline %s must have at least one receiver", name))
+		}
+		if pipeline == nil || len(pipeline.Exporters) == 0 {
+			errs = append(errs, fmt.Errorf("pipeline %s must have at least one exporter", name))
+		}
+	}
+	if _, err := s.MetricsLevel(); err != nil {
+		errs = append(errs, err)
+	}
+	for _, extension := range s.Extensions {
+		if _, ok := defined.ComponentsOfKind(KindExtension)[extension]; !ok {
+			errs = append(errs, fmt.Errorf("extension %s is enabled but not defined", extension))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateAgainstSupported checks that the type of every enabled component is in the given set of supported types for
+// its kind, such as the components built into a collector distribution. Kinds absent from the set aren't checked.
+// Connectors are only checked as connectors, not as the receivers and exporters they're used as.
+func (c *Config) ValidateAgainstSupported(supported map[ComponentKind]map[string]struct{}) error {
+	enabledComponents := c.GetEnabledComponents()
+	var errs []error
+	for _, kind := range AllComponentKinds() {
+		supportedTypes, ok := supported[kind]
+		if !ok {
+			continue
+		}
+		var unsupported []string
+		for id := range enabledComponents[kind] {
+			if kind == KindReceiver || kind == KindExporter {
+				if _, isConnector := enabledComponents[KindConnector][id]; isConnector {
+					continue
+				}
+			}
+			if _, ok := supportedTypes[components.ComponentType(id)]; !ok {
+				unsupported = append(unsupported, id)
+			}
+		}
+		if len(unsupported) > 0 {
+			sort.Strings(unsupported)
+			errs = append(errs, fmt.Errorf("unsupported %ss: %s", kind, strings.Join(unsupported, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
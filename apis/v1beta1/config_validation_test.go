@@ -0,0 +1,1024 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ValidateTelemetryPort(t *testing.T) {
+	newConfig := func(telemetryAddress, exporterEndpoint string) *Config {
+		return &Config{
+			Exporters: AnyConfig{
+				Object: map[string]interface{}{
+					"prometheus": map[string]interface{}{
+						"endpoint": exporterEndpoint,
+					},
+				},
+			},
+			Service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"address": telemetryAddress,
+						},
+					},
+				},
+				Pipelines: map[string]*Pipeline{
+					"metrics": {
+						Receivers: []string{"otlp"},
+						Exporters: []string{"prometheus"},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		config      *Config
+		expectedErr string
+	}{
+		{
+			desc:        "conflicting ports",
+			config:      newConfig("0.0.0.0:8888", "0.0.0.0:8888"),
+			expectedErr: "the telemetry metrics port 8888 conflicts with the prometheus port",
+		},
+		{
+			desc:   "distinct ports",
+			config: newConfig("0.0.0.0:8888", "0.0.0.0:8889"),
+		},
+		{
+			desc:   "telemetry port is an env var",
+			config: newConfig("0.0.0.0:${env:METRICS_PORT}", "0.0.0.0:8888"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidatePorts(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+						"http": map[string]interface{}{},
+					},
+				},
+				"otlp/averylongname": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"otlp/anotherlongname": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{},
+					},
+				},
+				"zipkin": map[string]interface{}{
+					"endpoint": "0.0.0.0:4318",
+				},
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"endpoint": "0.0.0.0:8888",
+				},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp", "otlp/averylongname", "otlp/anotherlongname", "zipkin"},
+					Exporters: []string{"prometheus"},
+				},
+			},
+		},
+	}
+
+	err := c.ValidatePorts(logr.Discard())
+	assert.EqualError(t, err, strings.Join([]string{
+		"the telemetry metrics port 8888 conflicts with the prometheus port, set service.telemetry.metrics.address to use another port",
+		"port 4317/TCP is used by several ports: otlp-grpc, port-4317, port-4317",
+		"port 4318/TCP is used by several ports: otlp-http, zipkin",
+		"port name port-4317 is used by 2 ports",
+	}, "\n"))
+
+	c.Service.SetMetricsAddress("0.0.0.0", 8889)
+	delete(c.Receivers.Object, "otlp/averylongname")
+	delete(c.Receivers.Object, "otlp/anotherlongname")
+	c.Receivers.Object["zipkin"] = map[string]interface{}{}
+	c.Service.Pipelines["traces"].Receivers = []string{"otlp", "zipkin"}
+	assert.NoError(t, c.ValidatePorts(logr.Discard()))
+}
+
+func TestConfig_ValidateTelemetryMetricsExposition(t *testing.T) {
+	readers := []interface{}{
+		map[string]interface{}{
+			"pull": map[string]interface{}{
+				"exporter": map[string]interface{}{
+					"prometheus": map[string]interface{}{
+						"host": "0.0.0.0",
+						"port": 8888,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		metrics     map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc:        "address and readers",
+			metrics:     map[string]interface{}{"address": "0.0.0.0:8888", "readers": readers},
+			expectedErr: "service.telemetry.metrics sets both address and readers",
+		},
+		{
+			desc:    "address only",
+			metrics: map[string]interface{}{"address": "0.0.0.0:8888"},
+		},
+		{
+			desc:    "readers only",
+			metrics: map[string]interface{}{"readers": readers},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Service: Service{
+					Telemetry: &AnyConfig{
+						Object: map[string]interface{}{
+							"metrics": tt.metrics,
+						},
+					},
+				},
+			}
+			err := c.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateTelemetryMetricsExpositionAfterDefaults(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Telemetry: &AnyConfig{
+				Object: map[string]interface{}{
+					"metrics": map[string]interface{}{
+						"readers": []interface{}{
+							map[string]interface{}{
+								"pull": map[string]interface{}{
+									"exporter": map[string]interface{}{
+										"prometheus": map[string]interface{}{"host": "0.0.0.0", "port": 8888},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, c.ApplyDefaults(logr.Discard()))
+	assert.NotContains(t, c.Service.Telemetry.Object["metrics"], "address")
+	assert.NoError(t, c.Validate())
+}
+
+func TestConfig_ValidateTelemetryLevel(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		telemetry   *AnyConfig
+		expectedErr string
+	}{
+		{
+			desc: "unknown level",
+			telemetry: &AnyConfig{
+				Object: map[string]interface{}{
+					"metrics": map[string]interface{}{"level": "verbose"},
+				},
+			},
+			expectedErr: `unknown telemetry metrics level "verbose", must be one of: none, basic, normal, detailed`,
+		},
+		{
+			desc: "valid level",
+			telemetry: &AnyConfig{
+				Object: map[string]interface{}{
+					"metrics": map[string]interface{}{"level": "detailed"},
+				},
+			},
+		},
+		{
+			desc: "no telemetry",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Service: Service{
+					Telemetry: tt.telemetry,
+				},
+			}
+			if tt.expectedErr == "" {
+				assert.NoError(t, c.ValidateTelemetryLevel())
+				assert.NoError(t, c.Validate())
+				return
+			}
+			assert.EqualError(t, c.ValidateTelemetryLevel(), tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateConnectorSignalCompatibility(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		pipelines   map[string]*Pipeline
+		expectedErr string
+	}{
+		{
+			desc: "traces to metrics",
+			pipelines: map[string]*Pipeline{
+				"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"spanmetrics"}},
+				"metrics": {Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+			},
+		},
+		{
+			desc: "exporter in a metrics pipeline",
+			pipelines: map[string]*Pipeline{
+				"metrics":         {Receivers: []string{"otlp"}, Exporters: []string{"spanmetrics"}},
+				"metrics/derived": {Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+			},
+			expectedErr: "connector spanmetrics can't be used as an exporter in pipeline metrics, it only accepts traces",
+		},
+		{
+			desc: "receiver in a logs pipeline",
+			pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"spanmetrics"}},
+				"logs":   {Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+			},
+			expectedErr: "connector spanmetrics can't be used as a receiver in pipeline logs, it only emits metrics",
+		},
+		{
+			desc: "forward across signals",
+			pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"forward"}},
+				"logs":   {Receivers: []string{"forward"}, Exporters: []string{"debug"}},
+			},
+			expectedErr: "connector forward can't connect pipeline traces to pipeline logs, it only turns traces into traces",
+		},
+		{
+			desc: "unknown connector type",
+			pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"custom"}},
+				"logs":   {Receivers: []string{"custom"}, Exporters: []string{"debug"}},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{Object: map[string]interface{}{"otlp": nil}},
+				Exporters: AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Connectors: &AnyConfig{
+					Object: map[string]interface{}{
+						"spanmetrics": nil,
+						"forward":     nil,
+						"custom":      nil,
+					},
+				},
+				Service: Service{Pipelines: tt.pipelines},
+			}
+			if tt.expectedErr == "" {
+				assert.NoError(t, c.ValidateConnectorSignalCompatibility())
+				assert.NoError(t, c.Validate())
+				return
+			}
+			assert.EqualError(t, c.ValidateConnectorSignalCompatibility(), tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateExporterEndpoints(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":            map[string]interface{}{"endpoint": "backend:4317"},
+				"otlp/malformed":  map[string]interface{}{"endpoint": "http//backend"},
+				"otlp/env":        map[string]interface{}{"endpoint": "${env:OTLP_ENDPOINT}"},
+				"otlphttp":        map[string]interface{}{"endpoint": "https://backend:4318"},
+				"otlphttp/scheme": map[string]interface{}{"endpoint": "backend:4318"},
+				"zipkin":          map[string]interface{}{"endpoint": "http://"},
+				"debug":           map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Exporters: []string{"otlp", "otlp/malformed", "otlp/env", "otlphttp", "otlphttp/scheme", "zipkin", "debug"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		`exporter otlp/malformed endpoint "http//backend" is neither a URL nor a host:port`,
+		`exporter otlphttp/scheme endpoint "backend:4318" must be a URL with a scheme, such as https://backend:4318`,
+		`exporter zipkin endpoint "http://" is not a valid URL`,
+	}, c.ValidateExporterEndpoints(logr.Discard()))
+}
+
+func TestService_Validate(t *testing.T) {
+	defined := Config{
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check": map[string]interface{}{},
+			},
+		},
+	}
+	validPipelines := map[string]*Pipeline{
+		"traces": {
+			Receivers: []string{"otlp"},
+			Exporters: []string{"debug"},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc         string
+		service      Service
+		expectedErrs []string
+	}{
+		{
+			desc: "valid",
+			service: Service{
+				Extensions: []string{"health_check"},
+				Pipelines:  validPipelines,
+			},
+		},
+		{
+			desc:         "empty pipelines map",
+			service:      Service{Pipelines: map[string]*Pipeline{}},
+			expectedErrs: []string{"service.pipelines must define at least one pipeline"},
+		},
+		{
+			desc: "pipeline without receivers or exporters",
+			service: Service{
+				Pipelines: map[string]*Pipeline{
+					"traces": {Processors: []string{"batch"}},
+					"logs":   nil,
+				},
+			},
+			expectedErrs: []string{
+				"pipeline logs must have at least one receiver",
+				"pipeline logs must have at least one exporter",
+				"pipeline traces must have at least one receiver",
+				"pipeline traces must have at least one exporter",
+			},
+		},
+		{
+			desc: "invalid telemetry level",
+			service: Service{
+				Telemetry: &AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "verbose",
+						},
+					},
+				},
+				Pipelines: validPipelines,
+			},
+			expectedErrs: []string{`unknown telemetry metrics level "verbose"`},
+		},
+		{
+			desc: "undefined extension",
+			service: Service{
+				Extensions: []string{"health_check", "pprof"},
+				Pipelines:  validPipelines,
+			},
+			expectedErrs: []string{"extension pprof is enabled but not defined"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.service.Validate(defined)
+			if len(tt.expectedErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, expectedErr := range tt.expectedErrs {
+				assert.ErrorContains(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAgainstSupported(t *testing.T) {
+	core := map[ComponentKind]map[string]struct{}{
+		KindReceiver:  {"otlp": {}},
+		KindExporter:  {"otlp": {}, "debug": {}},
+		KindProcessor: {"batch": {}},
+		KindConnector: {"forward": {}},
+	}
+	newConfig := func(exporter string) *Config {
+		return &Config{
+			Connectors: &AnyConfig{
+				Object: map[string]interface{}{
+					"forward": map[string]interface{}{},
+				},
+			},
+			Service: Service{
+				Extensions: []string{"health_check"},
+				Pipelines: map[string]*Pipeline{
+					"traces": {
+						Receivers:  []string{"otlp"},
+						Processors: []string{"batch"},
+						Exporters:  []string{"forward"},
+					},
+					"traces/2": {
+						Receivers: []string{"forward"},
+						Exporters: []string{exporter},
+					},
+				},
+			},
+		}
+	}
+
+	assert.NoError(t, newConfig("otlp/2").ValidateAgainstSupported(core))
+	assert.EqualError(t, newConfig("awsxray").ValidateAgainstSupported(core), "unsupported exporters: awsxray")
+}
+
+func TestConfig_OrphanConnectors(t *testing.T) {
+	c := &Config{
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"count":   map[string]interface{}{},
+				"forward": map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			// An extension sharing its ID with a connector doesn't make the connector used.
+			Extensions: []string{"forward"},
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"count"},
+				},
+				"metrics": {
+					Receivers:  []string{"count"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"otlp"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"forward"}, c.OrphanConnectors())
+	assert.Equal(t, []string{"connectors defined but not used by any pipeline: forward"}, c.Warnings())
+
+	c.Connectors = nil
+	assert.Empty(t, c.OrphanConnectors())
+	assert.Empty(t, c.Warnings())
+}
+
+func TestConfig_ProcessorOrderWarnings(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Processors: []string{"batch", "memory_limiter"},
+				},
+				"metrics": {
+					Processors: []string{"memory_limiter", "transform", "batch"},
+				},
+				"logs": {
+					Processors: []string{"transform", "memory_limiter/logs", "batch"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	expected := []string{
+		"pipeline logs: processor memory_limiter/logs should be the first processor",
+		"pipeline traces: processor memory_limiter should be the first processor",
+		"pipeline traces: batch processors should come after memory_limiter processors",
+	}
+	assert.Equal(t, expected, c.ProcessorOrderWarnings())
+	assert.Equal(t, expected, c.Warnings())
+}
+
+func TestConfig_ValidatePipelineProcessorUniqueness(t *testing.T) {
+	c := &Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch", "transform", "batch", "batch"},
+					Exporters:  []string{"otlp", "debug"},
+				},
+				"metrics": {
+					Receivers: []string{"otlp", "prometheus"},
+					Exporters: []string{"otlp", "debug", "otlp"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	warnings, err := c.ValidatePipelineProcessorUniqueness()
+	assert.Equal(t, []string{"pipeline traces: processor batch is listed more than once"}, warnings)
+	assert.EqualError(t, err, "pipeline metrics: exporter otlp is listed more than once")
+	assert.Contains(t, c.Warnings(), "pipeline traces: processor batch is listed more than once")
+	assert.ErrorContains(t, c.Validate(), "pipeline metrics: exporter otlp is listed more than once")
+
+	delete(c.Service.Pipelines, "metrics")
+	_, err = c.ValidatePipelineProcessorUniqueness()
+	assert.NoError(t, err)
+}
+
+func TestConfig_ValidateExtensionProbeSingleton(t *testing.T) {
+	c := &Config{
+		Extensions: &AnyConfig{
+			Object: map[string]interface{}{
+				"health_check":   map[string]interface{}{},
+				"health_check/2": map[string]interface{}{"endpoint": "0.0.0.0:13134"},
+				"pprof":          map[string]interface{}{},
+			},
+		},
+		Service: Service{
+			Extensions: []string{"pprof", "health_check"},
+		},
+	}
+	assert.NoError(t, c.ValidateExtensionProbeSingleton(logr.Discard()))
+
+	c.Service.Extensions = append(c.Service.Extensions, "health_check/2")
+	expectedErr := "only one extension may provide the liveness probe, found: health_check, health_check/2\n" +
+		"only one extension may provide the readiness probe, found: health_check, health_check/2"
+	assert.EqualError(t, c.ValidateExtensionProbeSingleton(logr.Discard()), expectedErr)
+	assert.ErrorContains(t, c.Validate(), expectedErr)
+}
+
+func TestConfig_ValidateExtensionDeps(t *testing.T) {
+	c := &Config{
+		Extensions: &AnyConfig{Object: map[string]interface{}{
+			"ack":          map[string]interface{}{"storage": "file_storage"},
+			"file_storage": map[string]interface{}{"directory": "/var/lib/otelcol"},
+			"ack/memory":   nil,
+		}},
+		Service: Service{Extensions: []string{"ack", "ack/memory"}},
+	}
+	expectedErr := "extension ack depends on extension file_storage, set in storage, which isn't enabled"
+	assert.EqualError(t, c.ValidateExtensionDeps(), expectedErr)
+	assert.ErrorContains(t, c.Validate(), expectedErr)
+
+	c.Service.Extensions = append(c.Service.Extensions, "file_storage")
+	assert.NoError(t, c.ValidateExtensionDeps())
+}
+
+func TestConfig_ValidateForProfile(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":          nil,
+				"hostmetrics":   nil,
+				"hostmetrics/2": nil,
+				"filelog":       nil,
+			},
+		},
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"debug": nil,
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"metrics": {
+					Receivers: []string{"otlp", "hostmetrics", "hostmetrics/2"},
+					Exporters: []string{"debug"},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, c.ValidateForProfile(ProfileDefault))
+	assert.EqualError(t, c.ValidateForProfile(ProfileServerless),
+		"receiver hostmetrics can't be used with the serverless profile\nreceiver hostmetrics/2 can't be used with the serverless profile")
+	assert.EqualError(t, c.ValidateForProfile("edge"), `unknown profile "edge", must be one of: default, serverless`)
+
+	c.Service.Pipelines["metrics"].Receivers = []string{"otlp"}
+	assert.NoError(t, c.ValidateForProfile(ProfileServerless), "disabled components aren't checked")
+}
+
+func TestConfig_ValidateIDs(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		receiver    string
+		pipeline    string
+		expectedErr string
+	}{
+		{
+			desc:     "type only",
+			receiver: "otlp",
+			pipeline: "traces",
+		},
+		{
+			desc:     "type and name",
+			receiver: "otlp/2",
+			pipeline: "traces/my-app_2",
+		},
+		{
+			desc:     "dotted names",
+			receiver: "otlphttp/backend.example.com",
+			pipeline: "traces/team.a",
+		},
+		{
+			desc:     "slash in name",
+			receiver: "otlp//2",
+			pipeline: "traces/team/a",
+		},
+		{
+			desc:        "space",
+			receiver:    "otlp/my app",
+			pipeline:    "traces",
+			expectedErr: `receiver ID "otlp/my app" is invalid: the name "my app" must be 1 to 1024 characters long, excluding whitespace, control characters and symbols`,
+		},
+		{
+			desc:        "symbol",
+			receiver:    "otlp/a+b",
+			pipeline:    "traces",
+			expectedErr: `receiver ID "otlp/a+b" is invalid: the name "a+b" must be 1 to 1024 characters long, excluding whitespace, control characters and symbols`,
+		},
+		{
+			desc:        "long name",
+			receiver:    "otlp/" + strings.Repeat("a", 1025),
+			pipeline:    "traces",
+			expectedErr: `receiver ID "otlp/` + strings.Repeat("a", 1025) + `" is invalid: the name "` + strings.Repeat("a", 1025) + `" must be 1 to 1024 characters long, excluding whitespace, control characters and symbols`,
+		},
+		{
+			desc:        "invalid type",
+			receiver:    "otlp-http",
+			pipeline:    "traces",
+			expectedErr: `receiver ID "otlp-http" is invalid: the type "otlp-http" must match ^[a-zA-Z][0-9a-zA-Z_]{0,62}$`,
+		},
+		{
+			desc:        "invalid pipeline name",
+			receiver:    "otlp",
+			pipeline:    "traces/",
+			expectedErr: `pipeline name "traces/" is invalid: the name "" must be 1 to 1024 characters long, excluding whitespace, control characters and symbols`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{Object: map[string]interface{}{tt.receiver: nil}},
+				Service: Service{
+					Pipelines: map[string]*Pipeline{
+						tt.pipeline: {Receivers: []string{tt.receiver}},
+					},
+				},
+			}
+			if tt.expectedErr == "" {
+				assert.NoError(t, c.ValidateIDs())
+				assert.NoError(t, c.Validate())
+				return
+			}
+			assert.EqualError(t, c.ValidateIDs(), tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateReceiverCreatorScope(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		receiverConfig map[string]interface{}
+		extensions     []string
+		expectedErr    string
+	}{
+		{
+			desc:        "no observer",
+			extensions:  []string{"health_check"},
+			expectedErr: "receiver receiver_creator requires an observer extension, such as k8s_observer, to be enabled",
+		},
+		{
+			desc:       "any observer",
+			extensions: []string{"health_check", "k8s_observer"},
+		},
+		{
+			desc:           "watched observer enabled",
+			receiverConfig: map[string]interface{}{"watch_observers": []interface{}{"host_observer/2"}},
+			extensions:     []string{"host_observer/2"},
+		},
+		{
+			desc:           "watched observer not enabled",
+			receiverConfig: map[string]interface{}{"watch_observers": []interface{}{"k8s_observer"}},
+			extensions:     []string{"host_observer"},
+			expectedErr:    "receiver receiver_creator watches the observer k8s_observer, which isn't an enabled observer extension",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{Object: map[string]interface{}{"receiver_creator": tt.receiverConfig}},
+				Extensions: &AnyConfig{
+					Object: map[string]interface{}{
+						"health_check":    nil,
+						"k8s_observer":    nil,
+						"host_observer":   nil,
+						"host_observer/2": nil,
+					},
+				},
+				Service: Service{
+					Extensions: tt.extensions,
+					Pipelines: map[string]*Pipeline{
+						"metrics": {Receivers: []string{"receiver_creator"}},
+					},
+				},
+			}
+			if tt.expectedErr == "" {
+				assert.NoError(t, c.ValidateReceiverCreatorScope())
+				return
+			}
+			assert.EqualError(t, c.ValidateReceiverCreatorScope(), tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_MissingBatchProcessorPipelines(t *testing.T) {
+	c := &Config{
+		Connectors: &AnyConfig{
+			Object: map[string]interface{}{
+				"spanmetrics": nil,
+			},
+		},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"memory_limiter"},
+					Exporters:  []string{"otlp", "spanmetrics"},
+				},
+				"traces/connector-only": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"spanmetrics"},
+				},
+				"metrics": {
+					Receivers:  []string{"spanmetrics"},
+					Processors: []string{"memory_limiter", "batch/metrics"},
+					Exporters:  []string{"prometheus"},
+				},
+				"logs": {
+					Receivers: []string{"otlp"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"traces"}, c.MissingBatchProcessorPipelines())
+	assert.Contains(t, c.Warnings(), "pipelines exporting data without a batch processor: traces")
+}
+
+func TestConfig_PipelinesWithoutRealExporter(t *testing.T) {
+	c := &Config{
+		Connectors: &AnyConfig{Object: map[string]interface{}{"spanmetrics": nil}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"debug"},
+				},
+				"traces/spanmetrics": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"debug/detailed", "spanmetrics"},
+				},
+				"metrics": {
+					Receivers: []string{"spanmetrics"},
+					Exporters: []string{"debug", "prometheus"},
+				},
+				"logs": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"nop", "logging"},
+				},
+				"logs/empty": {
+					Receivers: []string{"otlp"},
+				},
+				"nil": nil,
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"logs", "traces"}, c.PipelinesWithoutRealExporter())
+	assert.Contains(t, c.Warnings(), "pipelines not exporting data to any backend: logs, traces")
+}
+
+func TestConfig_EmptyComponentsWithRequiredFields(t *testing.T) {
+	c := &Config{
+		Exporters: AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":     nil,
+				"otlp/2":   map[string]interface{}{},
+				"otlp/set": map[string]interface{}{"endpoint": "backend:4317"},
+				"debug":    nil,
+			},
+		},
+	}
+
+	assert.Equal(t, []EmptyComponent{
+		{Path: "exporters.otlp", RequiredFields: []string{"endpoint"}},
+		{Path: "exporters.otlp/2", RequiredFields: []string{"endpoint"}},
+	}, c.EmptyComponentsWithRequiredFields())
+	assert.Contains(t, c.Warnings(), "exporters.otlp has no configuration but requires: endpoint")
+}
+
+func TestConfig_ValidateComponentConfigs(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		otlp        interface{}
+		expectedErr string
+	}{
+		{
+			desc: "grpc",
+			otlp: map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}},
+		},
+		{
+			desc: "no protocols block",
+			otlp: nil,
+		},
+		{
+			desc:        "empty protocols",
+			otlp:        map[string]interface{}{"protocols": map[string]interface{}{}},
+			expectedErr: "invalid receiver otlp: protocols must set at least one of: grpc, http",
+		},
+		{
+			desc:        "null protocols",
+			otlp:        map[string]interface{}{"protocols": nil},
+			expectedErr: "invalid receiver otlp: protocols must set at least one of: grpc, http",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{Object: map[string]interface{}{"otlp": tt.otlp}},
+			}
+			if tt.expectedErr == "" {
+				assert.NoError(t, c.ValidateComponentConfigs(logr.Discard()))
+				return
+			}
+			assert.EqualError(t, c.ValidateComponentConfigs(logr.Discard()), tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateNoDuplicateTelemetryAddress(t *testing.T) {
+	reader := func(host string, port int) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"pull": map[string]interface{}{
+					"exporter": map[string]interface{}{
+						"prometheus": map[string]interface{}{"host": host, "port": port},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		metrics     map[string]interface{}
+		receivers   map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc:        "address and reader collide",
+			metrics:     map[string]interface{}{"address": "0.0.0.0:8888", "readers": reader("0.0.0.0", 8888)},
+			expectedErr: "service.telemetry.metrics.address 0.0.0.0:8888 duplicates the address of service.telemetry.metrics.readers[0]",
+		},
+		{
+			desc:        "wildcard address and specific reader host collide",
+			metrics:     map[string]interface{}{"address": ":8888", "readers": reader("127.0.0.1", 8888)},
+			expectedErr: "service.telemetry.metrics.address :8888 duplicates the address of service.telemetry.metrics.readers[0]",
+		},
+		{
+			desc:    "address and reader on different ports",
+			metrics: map[string]interface{}{"address": "0.0.0.0:8888", "readers": reader("0.0.0.0", 9999)},
+		},
+		{
+			desc:    "address and receiver collide",
+			metrics: map[string]interface{}{"address": "0.0.0.0:4317"},
+			receivers: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+					},
+				},
+				"zipkin": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			},
+			expectedErr: "service.telemetry.metrics.address 0.0.0.0:4317 duplicates receivers.otlp.protocols.grpc.endpoint\n" +
+				"service.telemetry.metrics.address 0.0.0.0:4317 duplicates receivers.zipkin.endpoint",
+		},
+		{
+			desc:    "address and receiver on different hosts",
+			metrics: map[string]interface{}{"address": "10.0.0.1:4317"},
+			receivers: map[string]interface{}{
+				"zipkin": map[string]interface{}{"endpoint": "10.0.0.2:4317"},
+			},
+		},
+		{
+			desc:    "receiver with the default telemetry address",
+			metrics: map[string]interface{}{},
+			receivers: map[string]interface{}{
+				"zipkin": map[string]interface{}{"endpoint": "0.0.0.0:8888"},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Config{
+				Receivers: AnyConfig{Object: tt.receivers},
+				Service: Service{
+					Telemetry: &AnyConfig{Object: map[string]interface{}{"metrics": tt.metrics}},
+				},
+			}
+			err := c.ValidateNoDuplicateTelemetryAddress()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.ErrorContains(t, c.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfig_ValidateNoDuplicateTelemetryAddressAfterDefaults(t *testing.T) {
+	c := &Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}},
+		}},
+		Service: Service{
+			Telemetry: &AnyConfig{Object: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"readers": []interface{}{
+						map[string]interface{}{
+							"pull": map[string]interface{}{
+								"exporter": map[string]interface{}{
+									"prometheus": map[string]interface{}{"host": "0.0.0.0", "port": 8888},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+	require.NoError(t, c.ApplyDefaults(logr.Discard()))
+	assert.NoError(t, c.ValidateNoDuplicateTelemetryAddress())
+	assert.NoError(t, c.Validate())
+}
+
+func TestConfig_ValidateComponentConfigs_AttributeActions(t *testing.T) {
+	c := &Config{
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"attributes/env": map[string]interface{}{
+				"actions": []interface{}{
+					map[string]interface{}{"key": "env", "action": "insert", "value": "prod"},
+					map[string]interface{}{"key": "env", "action": "replace", "value": "prod"},
+				},
+			},
+		}},
+	}
+	assert.EqualError(t, c.ValidateComponentConfigs(logr.Discard()),
+		`invalid processor attributes/env: actions[1]: invalid action "replace", must be one of: insert, update, upsert, delete, hash, extract`)
+}
+
+func TestConfig_ValidateComponentConfigs_MemoryLimiter(t *testing.T) {
+	c := &Config{
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"memory_limiter": map[string]interface{}{"check_interval": "1s"},
+		}},
+	}
+	assert.EqualError(t, c.ValidateComponentConfigs(logr.Discard()),
+		"invalid processor memory_limiter: either limit_mib or limit_percentage must be set")
+
+	c.Processors.Object["memory_limiter"] = map[string]interface{}{"check_interval": "1s", "limit_percentage": 75, "spike_limit_percentage": 15}
+	assert.NoError(t, c.ValidateComponentConfigs(logr.Discard()))
+}
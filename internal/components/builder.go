@@ -28,6 +28,8 @@ type Settings[ComponentConfigType any] struct {
 	readinessGen    ProbeGenerator[ComponentConfigType]
 	defaultsApplier Defaulter[ComponentConfigType]
 	envVarGen       EnvVarGenerator[ComponentConfigType]
+	requiredFields  []string
+	validator       ConfigValidator[ComponentConfigType]
 }
 
 func NewEmptySettings[ComponentConfigType any]() *Settings[ComponentConfigType] {
@@ -125,6 +127,18 @@ func (b Builder[ComponentConfigType]) WithDefaultsApplier(defaultsApplier Defaul
 	})
 }
 
+func (b Builder[ComponentConfigType]) WithRequiredFields(fields ...string) Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.requiredFields = fields
+	})
+}
+
+func (b Builder[ComponentConfigType]) WithConfigValidator(validator ConfigValidator[ComponentConfigType]) Builder[ComponentConfigType] {
+	return append(b, func(o *Settings[ComponentConfigType]) {
+		o.validator = validator
+	})
+}
+
 func (b Builder[ComponentConfigType]) Build() (*GenericParser[ComponentConfigType], error) {
 	o := NewEmptySettings[ComponentConfigType]()
 	o.Apply(b...)
@@ -139,6 +153,7 @@ func (b Builder[ComponentConfigType]) Build() (*GenericParser[ComponentConfigTyp
 		livenessGen:     o.livenessGen,
 		readinessGen:    o.readinessGen,
 		defaultsApplier: o.defaultsApplier,
+		validator:       o.validator,
 		settings:        o,
 	}, nil
 }
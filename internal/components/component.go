@@ -20,6 +20,12 @@ var (
 	HttpProtocol          = "http"
 	UnsetPort       int32 = 0
 	PortNotFoundErr       = errors.New("port should not be empty")
+
+	// portEnvVarRegex matches on strings that end with a colon followed by the environment variable expansion syntax.
+	// So it should match on strings ending with: ":${env:POD_IP}" or ":${POD_IP}".
+	portEnvVarRegex = regexp.MustCompile(`:\${[env:]?.*}$`)
+	// explicitPortRegex matches on strings that end with a colon followed by 1 or more numbers (representing the port).
+	explicitPortRegex = regexp.MustCompile(`:(\d+$)`)
 )
 
 type PortRetriever interface {
@@ -42,6 +48,10 @@ type ProbeGenerator[ComponentConfigType any] func(logger logr.Logger, config Com
 // It's expected that type Config is the configuration used by a parser.
 type EnvVarGenerator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) ([]corev1.EnvVar, error)
 
+// ConfigValidator is a function that returns an error if the given config would prevent the collector from starting.
+// It's expected that type Config is the configuration used by a parser.
+type ConfigValidator[ComponentConfigType any] func(logger logr.Logger, config ComponentConfigType) error
+
 // Defaulter is a function that applies given defaults to the passed Config.
 // It's expected that type Config is the configuration used by a parser.
 type Defaulter[ComponentConfigType any] func(logger logr.Logger, defaultAddr string, defaultPort int32, config ComponentConfigType) (map[string]interface{}, error)
@@ -81,6 +91,40 @@ func PortFromEndpoint(endpoint string) (int32, error) {
 	return int32(port), err //nolint: gosec // disable G115, this is guaranteed to not overflow due to the bitSize in the ParseInt call
 }
 
+// SplitEndpoint gets the host and port number from an address without doing any validation regarding the address
+// itself.
+// It works even before env var expansion happens, when a simple `net.SplitHostPort` would fail because of the extra
+// colon from the env var, i.e. the address looks like "${env:POD_IP}:4317", "${env:POD_IP}", or "${POD_IP}".
+// If the address has no port, UnsetPort is returned. If the port itself is a variable, i.e.
+// "${env:POD_IP}:${env:PORT}", portIsEnv is true and UnsetPort is returned as the port can't be known ahead of time.
+// Surrounding whitespace and quotes, which templating tools sometimes leave in the value, are ignored.
+func SplitEndpoint(address string) (host string, port int32, portIsEnv bool, err error) {
+	address = TrimQuotes(address)
+	if loc := portEnvVarRegex.FindStringIndex(address); loc != nil {
+		return address[:loc[0]], UnsetPort, true, nil
+	}
+
+	loc := explicitPortRegex.FindStringSubmatchIndex(address)
+	if loc == nil {
+		return address, UnsetPort, false, nil
+	}
+
+	parsed, err := strconv.ParseInt(address[loc[2]:loc[3]], 10, 32)
+	if err != nil {
+		return "", UnsetPort, false, err
+	}
+	return address[:loc[0]], int32(parsed), false, nil //nolint: gosec // disable G115, this is guaranteed to not overflow due to the bitSize in the ParseInt call
+}
+
+// TrimQuotes removes the whitespace and the matching single or double quotes surrounding the given value.
+func TrimQuotes(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	return value
+}
+
 type ParserRetriever func(string) Parser
 
 type Parser interface {
@@ -104,6 +148,12 @@ type Parser interface {
 	// GetReadinessProbe returns a readiness probe set for the collector
 	GetReadinessProbe(logger logr.Logger, config interface{}) (*corev1.Probe, error)
 
+	// RequiredFields returns the dotted paths of the fields the component's configuration must set
+	RequiredFields() []string
+
+	// ValidateConfig returns an error if the component's configuration would prevent the collector from starting
+	ValidateConfig(logger logr.Logger, config interface{}) error
+
 	// ParserType returns the type of this parser
 	ParserType() string
 
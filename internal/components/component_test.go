@@ -60,6 +60,45 @@ func TestReceiverParsePortFromEndpoint(t *testing.T) {
 	}
 }
 
+func TestSplitEndpoint(t *testing.T) {
+	for _, tt := range []struct {
+		desc              string
+		address           string
+		expectedHost      string
+		expectedPort      int32
+		expectedPortIsEnv bool
+		errorExpected     bool
+	}{
+		{"literal", "0.0.0.0:8888", "0.0.0.0", 8888, false, false},
+		{"literal ipv6", "[::]:9090", "[::]", 9090, false, false},
+		{"just port", ":4317", "", 4317, false, false},
+		{"env var host", "${env:POD_IP}:4317", "${env:POD_IP}", 4317, false, false},
+		{"env var host ipv6", "[${POD_IP}]:1234", "[${POD_IP}]", 1234, false, false},
+		{"env var port", "localhost:${env:POD_PORT}", "localhost", components.UnsetPort, true, false},
+		{"env var host and port", "${env:POD_IP}:${env:POD_PORT}", "${env:POD_IP}", components.UnsetPort, true, false},
+		{"no port", "localhost", "localhost", components.UnsetPort, false, false},
+		{"no port env var", "${env:POD_IP}", "${env:POD_IP}", components.UnsetPort, false, false},
+		{"no port ipv6", "[::]", "[::]", components.UnsetPort, false, false},
+		{"overflow", "0.0.0.0:2147483648", "", components.UnsetPort, false, true},
+		{"double quoted", `"0.0.0.0:8888"`, "0.0.0.0", 8888, false, false},
+		{"single quoted ipv6", `'[::1]:9090'`, "[::1]", 9090, false, false},
+		{"quoted with whitespace", ` " localhost:4317 " `, "localhost", 4317, false, false},
+		{"unmatched quote", `"localhost:4317'`, `"localhost:4317'`, components.UnsetPort, false, false},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			host, port, portIsEnv, err := components.SplitEndpoint(tt.address)
+			if tt.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedHost, host)
+			assert.Equal(t, tt.expectedPort, port)
+			assert.Equal(t, tt.expectedPortIsEnv, portIsEnv)
+		})
+	}
+}
+
 func TestGetPortsForConfig(t *testing.T) {
 	type args struct {
 		config    map[string]interface{}
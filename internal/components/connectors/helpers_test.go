@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectors
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+func TestParserForReturns(t *testing.T) {
+	const testComponentName = "test"
+	parser := ParserFor(testComponentName)
+	assert.Equal(t, "test", parser.ParserType())
+	assert.Equal(t, "__test", parser.ParserName())
+	envVars, err := parser.GetEnvironmentVariables(logr.Discard(), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, envVars, 0) // Should use the nop parser
+}
+
+func TestCanRegister(t *testing.T) {
+	const testComponentName = "test"
+	Register(testComponentName, components.NewBuilder[any]().WithName(testComponentName).
+		WithEnvVarGen(func(logr.Logger, any) ([]corev1.EnvVar, error) {
+			return []corev1.EnvVar{{Name: "TEST", Value: "test"}}, nil
+		}).MustBuild())
+	assert.True(t, IsRegistered(testComponentName+"/2"))
+	parser := ParserFor(testComponentName)
+	assert.Equal(t, "test", parser.ParserType())
+	envVars, err := parser.GetEnvironmentVariables(logr.Discard(), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.EnvVar{{Name: "TEST", Value: "test"}}, envVars)
+}
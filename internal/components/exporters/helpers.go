@@ -9,7 +9,10 @@ import (
 
 // registry holds a record of all known receiver parsers.
 var registry = map[string]components.Parser{
-	"prometheus": components.NewSinglePortParserBuilder("prometheus", 8888).MustBuild(),
+	"prometheus":            components.NewSinglePortParserBuilder("prometheus", 8888).MustBuild(),
+	"otlp":                  components.NewBuilder[any]().WithName("otlp").WithRequiredFields("endpoint").MustBuild(),
+	"prometheusremotewrite": components.NewBuilder[any]().WithName("prometheusremotewrite").WithRequiredFields("endpoint").MustBuild(),
+	"zipkin":                components.NewBuilder[any]().WithName("zipkin").WithRequiredFields("endpoint").MustBuild(),
 }
 
 // ParserFor returns a parser builder for the given exporter name.
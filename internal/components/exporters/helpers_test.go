@@ -97,3 +97,19 @@ func TestExporterComponentParsers(t *testing.T) {
 		})
 	}
 }
+
+func TestExporterRequiredFields(t *testing.T) {
+	for _, tt := range []struct {
+		exporterName string
+		expected     []string
+	}{
+		{"otlp", []string{"endpoint"}},
+		{"otlp/2", []string{"endpoint"}},
+		{"zipkin", []string{"endpoint"}},
+		{"debug", nil},
+	} {
+		t.Run(tt.exporterName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParserFor(tt.exporterName).RequiredFields())
+		})
+	}
+}
@@ -15,6 +15,7 @@ var registry = map[string]components.Parser{
 	"health_check": components.NewBuilder[healthcheckV1Config]().
 		WithName("health_check").
 		WithPort(13133).
+		WithAppProtocol(&components.HttpProtocol).
 		WithReadinessGen(healthCheckV1Probe).
 		WithLivenessGen(healthCheckV1Probe).
 		WithPortParser(func(logger logr.Logger, name string, defaultPort *corev1.ServicePort, config healthcheckV1Config) ([]corev1.ServicePort, error) {
@@ -26,6 +27,11 @@ var registry = map[string]components.Parser{
 		MustBuild(),
 }
 
+// Register adds a new parser builder to the list of known builders.
+func Register(name string, p components.Parser) {
+	registry[name] = p
+}
+
 // ParserFor returns a parser builder for the given exporter name.
 func ParserFor(name string) components.Parser {
 	if parser, ok := registry[components.ComponentType(name)]; ok {
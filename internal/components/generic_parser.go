@@ -27,6 +27,7 @@ type GenericParser[T any] struct {
 	livenessGen     ProbeGenerator[T]
 	readinessGen    ProbeGenerator[T]
 	defaultsApplier Defaulter[T]
+	validator       ConfigValidator[T]
 }
 
 func (g *GenericParser[T]) GetDefaultConfig(logger logr.Logger, config interface{}) (interface{}, error) {
@@ -100,6 +101,24 @@ func (g *GenericParser[T]) Ports(logger logr.Logger, name string, config interfa
 	return g.portParser(logger, name, g.settings.GetServicePort(), parsed)
 }
 
+func (g *GenericParser[T]) ValidateConfig(logger logr.Logger, config interface{}) error {
+	if g.validator == nil {
+		return nil
+	}
+	var parsed T
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return err
+	}
+	return g.validator(logger, parsed)
+}
+
+func (g *GenericParser[T]) RequiredFields() []string {
+	if g.settings == nil {
+		return nil
+	}
+	return g.settings.requiredFields
+}
+
 func (g *GenericParser[T]) ParserType() string {
 	return ComponentType(g.name)
 }
@@ -5,6 +5,9 @@ package components
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/mitchellh/mapstructure"
@@ -40,14 +43,20 @@ func (m *MultiPortReceiver) Ports(logger logr.Logger, name string, config interf
 		return nil, err
 	}
 	var ports []corev1.ServicePort
-	for protocol, ec := range multiProtoEndpointCfg.Protocols {
+	// Each enabled protocol gets its own port, in the order of the protocols' names.
+	for _, protocol := range slices.Sorted(maps.Keys(multiProtoEndpointCfg.Protocols)) {
+		ec := multiProtoEndpointCfg.Protocols[protocol]
 		if defaultSvc, ok := m.portMappings[protocol]; ok {
 			port := defaultSvc.Port
 			if ec != nil {
 				port = ec.GetPortNumOrDefault(logger, port)
 			}
-			defaultSvc.Name = naming.PortName(fmt.Sprintf("%s-%s", name, protocol), port)
-			ports = append(ports, ConstructServicePort(defaultSvc, port))
+			// Work on a copy, the mapping is shared by every component using this parser.
+			svc := *defaultSvc
+			svc.Name = naming.PortName(fmt.Sprintf("%s-%s", name, protocol), port)
+			constructed := ConstructServicePort(&svc, port)
+			ec.applyNodePort(&constructed)
+			ports = append(ports, constructed)
 		} else {
 			return nil, fmt.Errorf("unknown protocol set: %s", protocol)
 		}
@@ -109,6 +118,27 @@ func (m *MultiPortReceiver) GetEnvironmentVariables(logger logr.Logger, config i
 	return nil, nil
 }
 
+func (m *MultiPortReceiver) RequiredFields() []string {
+	return nil
+}
+
+// ValidateConfig returns an error if the config has a protocols block without any protocol, as the receiver would
+// have nothing to listen on.
+func (m *MultiPortReceiver) ValidateConfig(logger logr.Logger, config interface{}) error {
+	cfg, ok := config.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	protocols, ok := cfg["protocols"]
+	if !ok {
+		return nil
+	}
+	if protocolsMap, isMap := protocols.(map[string]interface{}); protocols == nil || (isMap && len(protocolsMap) == 0) {
+		return fmt.Errorf("protocols must set at least one of: %s", strings.Join(slices.Sorted(maps.Keys(m.portMappings)), ", "))
+	}
+	return nil
+}
+
 type MultiPortBuilder[ComponentConfigType any] []Builder[ComponentConfigType]
 
 func NewMultiPortReceiverBuilder(name string) MultiPortBuilder[*MultiProtocolEndpointConfig] {
@@ -146,6 +146,31 @@ func TestMultiPortReceiver_Ports(t *testing.T) {
 			wantBuildErr: assert.NoError,
 			wantErr:      assert.NoError,
 		},
+		{
+			name: "port mapping with node port",
+			fields: fields{
+				name: "receiver2",
+				b:    components.NewMultiPortReceiverBuilder("receiver2").AddPortMapping(components.NewProtocolBuilder("http", 80)),
+			},
+			args: args{
+				config: map[string]interface{}{
+					"protocols": map[string]interface{}{
+						"http": map[string]interface{}{
+							"node_port": 30080,
+						},
+					},
+				},
+			},
+			want: []corev1.ServicePort{
+				{
+					Name:     "receiver2-http",
+					Port:     80,
+					NodePort: 30080,
+				},
+			},
+			wantBuildErr: assert.NoError,
+			wantErr:      assert.NoError,
+		},
 		{
 			name: "port mapping with target port",
 			fields: fields{
@@ -519,3 +544,22 @@ func TestMultiMustBuildPanics(t *testing.T) {
 		b.MustBuild()
 	})
 }
+
+func TestMultiPortReceiver_ValidateConfig(t *testing.T) {
+	parser := components.NewMultiPortReceiverBuilder("otlp").
+		AddPortMapping(components.NewProtocolBuilder("http", 4318)).
+		AddPortMapping(components.NewProtocolBuilder("grpc", 4317)).
+		MustBuild()
+
+	assert.NoError(t, parser.ValidateConfig(logr.Discard(), nil))
+	assert.NoError(t, parser.ValidateConfig(logr.Discard(), map[string]interface{}{}))
+	assert.NoError(t, parser.ValidateConfig(logr.Discard(), map[string]interface{}{
+		"protocols": map[string]interface{}{"http": nil},
+	}))
+	assert.EqualError(t, parser.ValidateConfig(logr.Discard(), map[string]interface{}{
+		"protocols": map[string]interface{}{},
+	}), "protocols must set at least one of: grpc, http")
+	assert.EqualError(t, parser.ValidateConfig(logr.Discard(), map[string]interface{}{
+		"protocols": nil,
+	}), "protocols must set at least one of: grpc, http")
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// attributeActions holds the actions the attributes and resource processors accept.
+var attributeActions = []string{"insert", "update", "upsert", "delete", "hash", "extract"}
+
+// AttributesConfig is a minimal struct needed for validating the actions of an attributes or resource processor
+// configuration. This only contains the fields necessary for validation, other fields can be added in the future.
+type AttributesConfig struct {
+	Actions []AttributeAction `mapstructure:"actions"`
+}
+
+// AttributeAction is a single action of an attributes or resource processor.
+type AttributeAction struct {
+	Key           string      `mapstructure:"key"`
+	Action        string      `mapstructure:"action"`
+	Value         interface{} `mapstructure:"value"`
+	Pattern       string      `mapstructure:"pattern"`
+	FromAttribute string      `mapstructure:"from_attribute"`
+	FromContext   string      `mapstructure:"from_context"`
+}
+
+// ValidateAttributeActions checks that every action has a valid action value along with the keys it requires. All the
+// problems found are reported together, each with the index of its action.
+func ValidateAttributeActions(_ logr.Logger, config AttributesConfig) error {
+	var errs []error
+	for i, action := range config.Actions {
+		if action.Key == "" && action.Pattern == "" {
+			errs = append(errs, fmt.Errorf("actions[%d]: key must be set", i))
+		}
+		switch action.Action {
+		case "":
+			errs = append(errs, fmt.Errorf("actions[%d]: action must be set", i))
+		case "insert", "update", "upsert":
+			if action.Value == nil && action.FromAttribute == "" && action.FromContext == "" {
+				errs = append(errs, fmt.Errorf("actions[%d]: %s action requires one of: value, from_attribute, from_context", i, action.Action))
+			}
+		case "extract":
+			if action.Pattern == "" {
+				errs = append(errs, fmt.Errorf("actions[%d]: extract action requires pattern", i))
+			}
+		default:
+			if !slices.Contains(attributeActions, action.Action) {
+				errs = append(errs, fmt.Errorf("actions[%d]: invalid action %q, must be one of: %s", i, action.Action, strings.Join(attributeActions, ", ")))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
+)
+
+func TestValidateAttributeActions(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		actions     []interface{}
+		expectedErr string
+	}{
+		{
+			desc: "valid actions",
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "action": "insert", "value": "prod"},
+				map[string]interface{}{"key": "user", "action": "upsert", "from_attribute": "user.id"},
+				map[string]interface{}{"key": "password", "action": "delete"},
+				map[string]interface{}{"pattern": "^secret_.*", "action": "hash"},
+				map[string]interface{}{"key": "url", "action": "extract", "pattern": "^(?P<host>.*)$"},
+			},
+		},
+		{
+			desc: "invalid action",
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "action": "insert", "value": "prod"},
+				map[string]interface{}{"key": "env", "action": "replace", "value": "prod"},
+			},
+			expectedErr: `actions[1]: invalid action "replace", must be one of: insert, update, upsert, delete, hash, extract`,
+		},
+		{
+			desc: "missing keys",
+			actions: []interface{}{
+				map[string]interface{}{"action": "update", "value": "prod"},
+				map[string]interface{}{"key": "env"},
+				map[string]interface{}{"key": "env", "action": "upsert"},
+				map[string]interface{}{"key": "url", "action": "extract"},
+			},
+			expectedErr: "actions[0]: key must be set\n" +
+				"actions[1]: action must be set\n" +
+				"actions[2]: upsert action requires one of: value, from_attribute, from_context\n" +
+				"actions[3]: extract action requires pattern",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, name := range []string{"attributes", "resource/custom"} {
+				err := processors.ProcessorFor(name).ValidateConfig(logr.Discard(), map[string]interface{}{"actions": tt.actions})
+				if tt.expectedErr == "" {
+					assert.NoError(t, err)
+					continue
+				}
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -28,6 +28,10 @@ func ProcessorFor(name string) components.Parser {
 }
 
 var componentParsers = []components.Parser{
+	components.NewBuilder[AttributesConfig]().WithName("attributes").WithConfigValidator(ValidateAttributeActions).MustBuild(),
+	components.NewBuilder[AttributesConfig]().WithName("resource").WithConfigValidator(ValidateAttributeActions).MustBuild(),
+	components.NewBuilder[TransformConfig]().WithName("transform").WithConfigValidator(ValidateTransformStatements).MustBuild(),
+	components.NewBuilder[MemoryLimiterConfig]().WithName("memory_limiter").WithConfigValidator(ValidateMemoryLimiter).MustBuild(),
 	components.NewBuilder[K8sAttributeConfig]().WithName("k8sattributes").WithRbacGen(GenerateK8SAttrRbacRules).MustBuild(),
 	components.NewBuilder[ResourceDetectionConfig]().WithName("resourcedetection").WithRbacGen(GenerateResourceDetectionRbacRules).MustBuild(),
 }
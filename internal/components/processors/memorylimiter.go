@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// MemoryLimiterConfig is a minimal struct needed for validating a memory_limiter processor configuration. This only
+// contains the fields necessary for validation, other fields can be added in the future.
+type MemoryLimiterConfig struct {
+	CheckInterval        string `mapstructure:"check_interval"`
+	LimitMiB             uint32 `mapstructure:"limit_mib"`
+	SpikeLimitMiB        uint32 `mapstructure:"spike_limit_mib"`
+	LimitPercentage      uint32 `mapstructure:"limit_percentage"`
+	SpikeLimitPercentage uint32 `mapstructure:"spike_limit_percentage"`
+}
+
+// ValidateMemoryLimiter checks that check_interval is set, and that the limits are set either in MiB or as a
+// percentage of the available memory, with a spike limit lower than the limit. All the problems found are reported
+// together.
+func ValidateMemoryLimiter(_ logr.Logger, config MemoryLimiterConfig) error {
+	var errs []error
+	if config.CheckInterval == "" {
+		errs = append(errs, errors.New("check_interval must be set"))
+	} else if interval, err := time.ParseDuration(config.CheckInterval); err != nil || interval <= 0 {
+		errs = append(errs, fmt.Errorf("check_interval %q must be a positive duration", config.CheckInterval))
+	}
+	usesMiB := config.LimitMiB > 0 || config.SpikeLimitMiB > 0
+	usesPercentage := config.LimitPercentage > 0 || config.SpikeLimitPercentage > 0
+	switch {
+	case usesMiB && usesPercentage:
+		errs = append(errs, errors.New("limit_mib and spike_limit_mib can't be combined with limit_percentage and spike_limit_percentage"))
+	case usesMiB:
+		errs = append(errs, validateMemoryLimits("limit_mib", config.LimitMiB, "spike_limit_mib", config.SpikeLimitMiB))
+	case usesPercentage:
+		if config.LimitPercentage > 100 {
+			errs = append(errs, fmt.Errorf("limit_percentage %d must not exceed 100", config.LimitPercentage))
+		}
+		errs = append(errs, validateMemoryLimits("limit_percentage", config.LimitPercentage, "spike_limit_percentage", config.SpikeLimitPercentage))
+	default:
+		errs = append(errs, errors.New("either limit_mib or limit_percentage must be set"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateMemoryLimits checks that the limit is set, and that the spike limit is lower than it.
+func validateMemoryLimits(limitField string, limit uint32, spikeField string, spike uint32) error {
+	if limit == 0 {
+		return fmt.Errorf("%s must be set along with %s", limitField, spikeField)
+	}
+	if spike >= limit {
+		return fmt.Errorf("%s %d must be lower than %s %d", spikeField, spike, limitField, limit)
+	}
+	return nil
+}
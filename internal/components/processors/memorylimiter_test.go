@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
+)
+
+func TestValidateMemoryLimiter(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc:   "limits in MiB",
+			config: map[string]interface{}{"check_interval": "1s", "limit_mib": 400, "spike_limit_mib": 100},
+		},
+		{
+			desc:   "limits as percentages",
+			config: map[string]interface{}{"check_interval": "1s", "limit_percentage": 75, "spike_limit_percentage": 15},
+		},
+		{
+			desc:   "limits decoded from JSON",
+			config: map[string]interface{}{"check_interval": "5s", "limit_mib": float64(400)},
+		},
+		{
+			desc:        "no limits",
+			config:      map[string]interface{}{"check_interval": "1s"},
+			expectedErr: "either limit_mib or limit_percentage must be set",
+		},
+		{
+			desc:        "no config",
+			expectedErr: "check_interval must be set\neither limit_mib or limit_percentage must be set",
+		},
+		{
+			desc:        "mixed limits",
+			config:      map[string]interface{}{"check_interval": "1s", "limit_mib": 400, "spike_limit_percentage": 15},
+			expectedErr: "limit_mib and spike_limit_mib can't be combined with limit_percentage and spike_limit_percentage",
+		},
+		{
+			desc:        "spike limit without limit",
+			config:      map[string]interface{}{"check_interval": "1s", "spike_limit_mib": 100},
+			expectedErr: "limit_mib must be set along with spike_limit_mib",
+		},
+		{
+			desc:        "spike limit above limit",
+			config:      map[string]interface{}{"check_interval": "1s", "limit_percentage": 20, "spike_limit_percentage": 25},
+			expectedErr: "spike_limit_percentage 25 must be lower than limit_percentage 20",
+		},
+		{
+			desc:        "percentage above 100",
+			config:      map[string]interface{}{"check_interval": "1s", "limit_percentage": 120},
+			expectedErr: "limit_percentage 120 must not exceed 100",
+		},
+		{
+			desc:        "invalid check interval",
+			config:      map[string]interface{}{"check_interval": "soon", "limit_mib": 400},
+			expectedErr: `check_interval "soon" must be a positive duration`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := processors.ProcessorFor("memory_limiter").ValidateConfig(logr.Discard(), tt.config)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// transformErrorModes holds the error modes the transform processor accepts.
+var transformErrorModes = []string{"ignore", "silent", "propagate"}
+
+// TransformConfig is a minimal struct needed for validating the statements of a transform processor configuration.
+// This only contains the fields necessary for validation, other fields can be added in the future. The statements of
+// each signal are either plain statements or groups of statements sharing a context, hence their untyped entries.
+type TransformConfig struct {
+	ErrorMode        string        `mapstructure:"error_mode"`
+	TraceStatements  []interface{} `mapstructure:"trace_statements"`
+	MetricStatements []interface{} `mapstructure:"metric_statements"`
+	LogStatements    []interface{} `mapstructure:"log_statements"`
+}
+
+// ValidateTransformStatements checks that the error mode is a valid one and that every statement of each signal is
+// set: either a non-empty plain statement, or a group with a valid context for the signal, when set, and a non-empty
+// list of non-empty statements. All the problems found are reported together, each with the index of its entry.
+func ValidateTransformStatements(_ logr.Logger, config TransformConfig) error {
+	var errs []error
+	if config.ErrorMode != "" && !slices.Contains(transformErrorModes, config.ErrorMode) {
+		errs = append(errs, fmt.Errorf("invalid error_mode %q, must be one of: %s", config.ErrorMode, strings.Join(transformErrorModes, ", ")))
+	}
+	for _, signal := range []struct {
+		field    string
+		contexts []string
+		entries  []interface{}
+	}{
+		{"trace_statements", []string{"resource", "scope", "span", "spanevent"}, config.TraceStatements},
+		{"metric_statements", []string{"resource", "scope", "metric", "datapoint"}, config.MetricStatements},
+		{"log_statements", []string{"resource", "scope", "log"}, config.LogStatements},
+	} {
+		for i, entry := range signal.entries {
+			errs = append(errs, validateTransformEntry(fmt.Sprintf("%s[%d]", signal.field, i), signal.contexts, entry)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateTransformEntry returns the problems of the given entry of a signal's statements, found at the given path,
+// whose context must be one of the given ones.
+func validateTransformEntry(path string, contexts []string, entry interface{}) []error {
+	switch entry := entry.(type) {
+	case string:
+		if strings.TrimSpace(entry) == "" {
+			return []error{fmt.Errorf("%s: statement must not be empty", path)}
+		}
+		return nil
+	case map[string]interface{}:
+		var errs []error
+		if context, ok := entry["context"]; ok {
+			if contextName, _ := context.(string); !slices.Contains(contexts, strings.ToLower(contextName)) {
+				errs = append(errs, fmt.Errorf("%s: invalid context %v, must be one of: %s", path, context, strings.Join(contexts, ", ")))
+			}
+		}
+		statements, _ := entry["statements"].([]interface{})
+		if len(statements) == 0 {
+			errs = append(errs, fmt.Errorf("%s: statements must be set", path))
+		}
+		for j, statement := range statements {
+			if statementText, _ := statement.(string); strings.TrimSpace(statementText) == "" {
+				errs = append(errs, fmt.Errorf("%s.statements[%d]: statement must not be empty", path, j))
+			}
+		}
+		return errs
+	default:
+		return []error{fmt.Errorf("%s: must be a statement or a group of statements", path)}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
+)
+
+func TestValidateTransformStatements(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc: "valid statements",
+			config: map[string]interface{}{
+				"error_mode": "ignore",
+				"trace_statements": []interface{}{
+					map[string]interface{}{
+						"context":    "span",
+						"statements": []interface{}{`set(name, "renamed")`},
+					},
+				},
+				"metric_statements": []interface{}{
+					map[string]interface{}{
+						"statements": []interface{}{`set(metric.description, "")`},
+					},
+				},
+				"log_statements": []interface{}{`set(log.severity_text, "INFO")`},
+			},
+		},
+		{
+			desc: "invalid statements",
+			config: map[string]interface{}{
+				"error_mode": "fail",
+				"trace_statements": []interface{}{
+					map[string]interface{}{
+						"context":    "datapoint",
+						"statements": []interface{}{`set(name, "renamed")`, ""},
+					},
+				},
+				"metric_statements": []interface{}{
+					map[string]interface{}{"context": "metric"},
+				},
+				"log_statements": []interface{}{" ", 42},
+			},
+			expectedErr: `invalid error_mode "fail", must be one of: ignore, silent, propagate` + "\n" +
+				"trace_statements[0]: invalid context datapoint, must be one of: resource, scope, span, spanevent\n" +
+				"trace_statements[0].statements[1]: statement must not be empty\n" +
+				"metric_statements[0]: statements must be set\n" +
+				"log_statements[0]: statement must not be empty\n" +
+				"log_statements[1]: must be a statement or a group of statements",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := processors.ProcessorFor("transform/custom").ValidateConfig(logr.Discard(), tt.config)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -138,7 +138,10 @@ var (
 		components.NewBuilder[k8sobjectsConfig]().WithName("k8sobjects").
 			WithRbacGen(generatek8sobjectsRbacRules).
 			MustBuild(),
-		NewScraperParser("prometheus"),
+		components.NewBuilder[prometheusConfig]().WithName("prometheus").
+			WithPort(components.UnsetPort).
+			WithConfigValidator(validatePrometheusConfig).
+			MustBuild(),
 		NewScraperParser("sshcheck"),
 		NewScraperParser("cloudfoundry"),
 		NewScraperParser("vcenter"),
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+)
+
+// defaultScrapeInterval is Prometheus' default scrape interval.
+const defaultScrapeInterval = "1m"
+
+type prometheusConfig struct {
+	Config prometheusScrapeConfigs `mapstructure:"config"`
+}
+
+type prometheusScrapeConfigs struct {
+	Global        prometheusScrapeSettings `mapstructure:"global"`
+	ScrapeConfigs []prometheusScrapeConfig `mapstructure:"scrape_configs"`
+}
+
+type prometheusScrapeSettings struct {
+	ScrapeInterval string `mapstructure:"scrape_interval"`
+	ScrapeTimeout  string `mapstructure:"scrape_timeout"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName                  string `mapstructure:"job_name"`
+	prometheusScrapeSettings `mapstructure:",squash"`
+}
+
+// validatePrometheusConfig checks the scrape configs embedded in a prometheus receiver the way Prometheus does: every
+// scrape config must have a job name, and no scrape timeout may be longer than its scrape interval. A scrape config
+// inherits the global interval it doesn't set, and a timeout left to its default is shortened to the interval. The
+// durations referencing environment variables are only known once the collector expands them, they aren't compared.
+// All the problems found are reported together.
+func validatePrometheusConfig(_ logr.Logger, cfg prometheusConfig) error {
+	var errs []error
+	global := cfg.Config.Global
+	globalInterval, globalIntervalKnown, err := parseScrapeDuration("config.global.scrape_interval", global.ScrapeInterval, defaultScrapeInterval)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if global.ScrapeTimeout != "" {
+		globalTimeout, known, err := parseScrapeDuration("config.global.scrape_timeout", global.ScrapeTimeout, "")
+		if err != nil {
+			errs = append(errs, err)
+		} else if known && globalIntervalKnown && globalTimeout > globalInterval {
+			errs = append(errs, fmt.Errorf("config.global.scrape_timeout %s is longer than config.global.scrape_interval %s", globalTimeout, globalInterval))
+		}
+	}
+	for i, scrapeConfig := range cfg.Config.ScrapeConfigs {
+		at := fmt.Sprintf("config.scrape_configs[%d]", i)
+		if scrapeConfig.JobName == "" {
+			errs = append(errs, fmt.Errorf("%s: job_name must be set", at))
+		} else {
+			at = fmt.Sprintf("%s (job %s)", at, scrapeConfig.JobName)
+		}
+		interval, intervalKnown := globalInterval, globalIntervalKnown
+		if scrapeConfig.ScrapeInterval != "" {
+			interval, intervalKnown, err = parseScrapeDuration(at+": scrape_interval", scrapeConfig.ScrapeInterval, "")
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if scrapeConfig.ScrapeTimeout == "" {
+			continue
+		}
+		timeout, known, err := parseScrapeDuration(at+": scrape_timeout", scrapeConfig.ScrapeTimeout, "")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if known && intervalKnown && timeout > interval {
+			errs = append(errs, fmt.Errorf("%s: scrape_timeout %s is longer than scrape_interval %s", at, timeout, interval))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseScrapeDuration parses the given Prometheus duration, found at the given field, falling back to the given default
+// when it isn't set. A duration referencing an environment variable isn't known, and isn't an error.
+func parseScrapeDuration(field, value, defaultValue string) (duration model.Duration, known bool, err error) {
+	if value == "" {
+		value = defaultValue
+	}
+	if strings.Contains(value, "${") {
+		return 0, false, nil
+	}
+	duration, err = model.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", field, err)
+	}
+	return duration, true, nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
+)
+
+func TestPrometheusValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc: "no scrape configs",
+		},
+		{
+			desc: "valid scrape configs",
+			config: map[string]interface{}{
+				"global": map[string]interface{}{"scrape_interval": "30s", "scrape_timeout": "20s"},
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"job_name": "inherited"},
+					map[string]interface{}{"job_name": "short", "scrape_interval": "5s"},
+					map[string]interface{}{"job_name": "explicit", "scrape_interval": "1d", "scrape_timeout": "1m"},
+				},
+			},
+		},
+		{
+			desc: "inverted interval and timeout",
+			config: map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"job_name": "app", "scrape_interval": "10s", "scrape_timeout": "30s"},
+				},
+			},
+			expectedErr: "config.scrape_configs[0] (job app): scrape_timeout 30s is longer than scrape_interval 10s",
+		},
+		{
+			desc: "inverted global interval and timeout",
+			config: map[string]interface{}{
+				"global": map[string]interface{}{"scrape_interval": "10s", "scrape_timeout": "15s"},
+			},
+			expectedErr: "config.global.scrape_timeout 15s is longer than config.global.scrape_interval 10s",
+		},
+		{
+			desc: "timeout longer than the inherited interval",
+			config: map[string]interface{}{
+				"global": map[string]interface{}{"scrape_interval": "15s"},
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"job_name": "app", "scrape_timeout": "20s"},
+				},
+			},
+			expectedErr: "config.scrape_configs[0] (job app): scrape_timeout 20s is longer than scrape_interval 15s",
+		},
+		{
+			desc: "missing job name and invalid duration",
+			config: map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"scrape_interval": "10s"},
+					map[string]interface{}{"job_name": "app", "scrape_interval": "often"},
+				},
+			},
+			expectedErr: "config.scrape_configs[0]: job_name must be set\n" +
+				`config.scrape_configs[1] (job app): scrape_interval: not a valid duration string: "often"`,
+		},
+		{
+			desc: "environment variables",
+			config: map[string]interface{}{
+				"global": map[string]interface{}{"scrape_interval": "${env:SCRAPE_INTERVAL}", "scrape_timeout": "10s"},
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"job_name": "inherited", "scrape_timeout": "30s"},
+					map[string]interface{}{"job_name": "app", "scrape_interval": "10s", "scrape_timeout": "${env:SCRAPE_TIMEOUT}"},
+				},
+			},
+		},
+		{
+			desc: "invalid global interval",
+			config: map[string]interface{}{
+				"global": map[string]interface{}{"scrape_interval": "often", "scrape_timeout": "sometimes"},
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"scrape_interval": "10s", "scrape_timeout": "30s"},
+				},
+			},
+			expectedErr: `config.global.scrape_interval: not a valid duration string: "often"` + "\n" +
+				`config.global.scrape_timeout: not a valid duration string: "sometimes"` + "\n" +
+				"config.scrape_configs[0]: job_name must be set\n" +
+				"config.scrape_configs[0]: scrape_timeout 30s is longer than scrape_interval 10s",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := receivers.ReceiverFor("prometheus").ValidateConfig(logr.Discard(), map[string]interface{}{"config": tt.config})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -23,6 +23,16 @@ var (
 type SingleEndpointConfig struct {
 	Endpoint      string `mapstructure:"endpoint,omitempty" yaml:"endpoint,omitempty"`
 	ListenAddress string `mapstructure:"listen_address,omitempty" yaml:"listen_address,omitempty"`
+	// NodePort pins the NodePort of the endpoint's port when the collector's service is exposed on the nodes. It's left
+	// to the cluster to assign when unset.
+	NodePort int32 `mapstructure:"node_port,omitempty" yaml:"node_port,omitempty"`
+}
+
+// applyNodePort sets the NodePort of the given port to the one configured, if any.
+func (g *SingleEndpointConfig) applyNodePort(port *corev1.ServicePort) {
+	if g != nil && g.NodePort > 0 {
+		port.NodePort = g.NodePort
+	}
 }
 
 func (g *SingleEndpointConfig) GetPortNumOrDefault(logger logr.Logger, p int32) int32 {
@@ -38,13 +48,30 @@ func (g *SingleEndpointConfig) GetPortNumOrDefault(logger logr.Logger, p int32)
 // are returned.
 func (g *SingleEndpointConfig) GetPortNum() (int32, error) {
 	if len(g.Endpoint) > 0 {
-		return PortFromEndpoint(g.Endpoint)
+		return portFromAddress(g.Endpoint)
 	} else if len(g.ListenAddress) > 0 {
-		return PortFromEndpoint(g.ListenAddress)
+		return portFromAddress(g.ListenAddress)
 	}
 	return UnsetPort, PortNotFoundErr
 }
 
+// portFromAddress gets the port of the given address with SplitEndpoint, falling back to PortFromEndpoint for the
+// addresses SplitEndpoint doesn't find a port in, such as URLs with a path. A port set by an environment variable isn't
+// known until the collector expands it, so it isn't found.
+func portFromAddress(address string) (int32, error) {
+	_, port, portIsEnv, err := SplitEndpoint(address)
+	if err != nil {
+		return UnsetPort, err
+	}
+	if portIsEnv {
+		return UnsetPort, PortNotFoundErr
+	}
+	if port == UnsetPort {
+		return PortFromEndpoint(address)
+	}
+	return port, nil
+}
+
 func ParseSingleEndpointSilent(logger logr.Logger, name string, defaultPort *corev1.ServicePort, singleEndpointConfig *SingleEndpointConfig) ([]corev1.ServicePort, error) {
 	return internalParseSingleEndpoint(logger, name, true, defaultPort, singleEndpointConfig)
 }
@@ -69,7 +96,9 @@ func internalParseSingleEndpoint(logger logr.Logger, name string, failSilently b
 	port := singleEndpointConfig.GetPortNumOrDefault(logger, defaultPort.Port)
 	svcPort := defaultPort
 	svcPort.Name = naming.PortName(name, port)
-	return []corev1.ServicePort{ConstructServicePort(svcPort, port)}, nil
+	constructed := ConstructServicePort(svcPort, port)
+	singleEndpointConfig.applyNodePort(&constructed)
+	return []corev1.ServicePort{constructed}, nil
 }
 
 func NewSinglePortParserBuilder(name string, port int32) Builder[*SingleEndpointConfig] {
@@ -84,6 +84,56 @@ func TestSingleEndpointConfig_GetPortNumOrDefault(t *testing.T) {
 			},
 			want: 9000, // Should return default port
 		},
+		{
+			name: "Test with ipv6 endpoint",
+			fields: fields{
+				Endpoint: "[::1]:4318",
+			},
+			args: args{
+				p: 9000,
+			},
+			want: 4318,
+		},
+		{
+			name: "Test with env var host",
+			fields: fields{
+				Endpoint: "${env:POD_IP}:4318",
+			},
+			args: args{
+				p: 9000,
+			},
+			want: 4318,
+		},
+		{
+			name: "Test with env var port",
+			fields: fields{
+				Endpoint: "0.0.0.0:${env:PORT}",
+			},
+			args: args{
+				p: 9000,
+			},
+			want: 9000, // Should return default port
+		},
+		{
+			name: "Test with quoted listen address",
+			fields: fields{
+				ListenAddress: `"0.0.0.0:9090"`,
+			},
+			args: args{
+				p: 9000,
+			},
+			want: 9090,
+		},
+		{
+			name: "Test with url endpoint",
+			fields: fields{
+				Endpoint: "http://localhost:8080/server-status?auto",
+			},
+			args: args{
+				p: 9000,
+			},
+			want: 8080,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -215,6 +265,22 @@ func TestSingleEndpointParser_Ports(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "ValidConfigWithNodePort",
+			fields: fields{
+				b: components.NewSinglePortParserBuilder("testparser", 8080),
+			},
+			args: args{
+				config: map[string]interface{}{
+					"endpoint":  "0.0.0.0:9090",
+					"node_port": 30090,
+				},
+			},
+			want: []corev1.ServicePort{
+				{Name: "testparser", Port: 9090, NodePort: 30090},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "ConfigWithFixins",
 			fields: fields{
@@ -34,7 +34,7 @@ type Config struct {
 func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1alpha1.TargetAllocator, options ...ta.TAOption) (string, error) {
 	collectorSpec := otelcol.Spec
 	taEnabled := targetAllocator != nil
-	cfgStr, err := collectorSpec.Config.Yaml()
+	cfgStr, err := collectorSpec.Config.Rendered().Yaml()
 	if err != nil {
 		return "", err
 	}
@@ -166,7 +166,7 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		)
 	}
 
-	if configEnvVars, err := otelcol.Spec.Config.GetEnvironmentVariables(logger); err != nil {
+	if configEnvVars, err := otelcol.Spec.Config.GetAllEnvironmentVariables(logger); err != nil {
 		logger.Error(err, "could not get the environment variables from the config")
 	} else {
 		envVars = append(envVars, configEnvVars...)
@@ -192,33 +192,31 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 
 func getConfigContainerPorts(logger logr.Logger, conf v1beta1.Config) (map[string]corev1.ContainerPort, error) {
 	ports := map[string]corev1.ContainerPort{}
-	ps, err := conf.GetAllPorts(logger)
+	ps, err := conf.GetContainerPorts(logger)
 	if err != nil {
 		return ports, err
 	}
-	if len(ps) > 0 {
-		for _, p := range ps {
-			truncName := naming.Truncate(p.Name, maxPortLen)
-			if p.Name != truncName {
-				logger.Info("truncating container port name",
-					"port.name.prev", p.Name, "port.name.new", truncName)
-			}
-			nameErrs := validation.IsValidPortName(truncName)
-			numErrs := validation.IsValidPortNum(int(p.Port))
-			if len(nameErrs) > 0 || len(numErrs) > 0 {
-				logger.Info("dropping invalid container port", "port.name", truncName, "port.num", p.Port,
-					"port.name.errs", nameErrs, "num.errs", numErrs)
-				continue
-			}
-			ports[truncName] = corev1.ContainerPort{
-				Name:          truncName,
-				ContainerPort: p.Port,
-				Protocol:      p.Protocol,
-			}
+	for _, p := range ps {
+		truncName := naming.Truncate(p.Name, maxPortLen)
+		if p.Name != truncName {
+			logger.Info("truncating container port name",
+				"port.name.prev", p.Name, "port.name.new", truncName)
 		}
+		nameErrs := validation.IsValidPortName(truncName)
+		numErrs := validation.IsValidPortNum(int(p.ContainerPort))
+		if len(nameErrs) > 0 || len(numErrs) > 0 {
+			logger.Info("dropping invalid container port", "port.name", truncName, "port.num", p.ContainerPort,
+				"port.name.errs", nameErrs, "num.errs", numErrs)
+			continue
+		}
+		p.Name = truncName
+		ports[truncName] = p
 	}
 
-	_, metricsPort, err := conf.Service.MetricsEndpoint(logger)
+	if !conf.Service.MetricsEnabled() {
+		return ports, nil
+	}
+	metricsPort, err := conf.Service.MetricsPort(logger)
 	if err != nil {
 		logger.Info("couldn't determine metrics port from configuration, using 8888 default value", "error", err)
 		metricsPort = 8888
@@ -23,6 +23,16 @@ func PodMonitor(params manifests.Params) (*monitoringv1.PodMonitor, error) {
 		return nil, nil
 	}
 
+	var endpoints []monitoringv1.PodMetricsEndpoint
+	// The collector's own metrics aren't exposed when their level is none.
+	if params.OtelCol.Spec.Config.Service.MetricsEnabled() {
+		endpoints = append(endpoints, monitoringv1.PodMetricsEndpoint{Port: "monitoring"})
+	}
+	endpoints = append(endpoints, metricsEndpointsFromConfig(params.Log, params.OtelCol)...)
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
 	name := naming.PodMonitor(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, nil)
 	selectorLabels := manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector)
@@ -41,12 +51,7 @@ func PodMonitor(params manifests.Params) (*monitoringv1.PodMonitor, error) {
 			Selector: metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
-			PodMetricsEndpoints: append(
-				[]monitoringv1.PodMetricsEndpoint{
-					{
-						Port: "monitoring",
-					},
-				}, metricsEndpointsFromConfig(params.Log, params.OtelCol)...),
+			PodMetricsEndpoints: endpoints,
 		},
 	}
 
@@ -62,6 +62,10 @@ func HeadlessService(params manifests.Params) (*corev1.Service, error) {
 }
 
 func MonitoringService(params manifests.Params) (*corev1.Service, error) {
+	if !params.OtelCol.Spec.Config.Service.MetricsEnabled() {
+		// The collector doesn't expose its metrics, there's nothing to monitor.
+		return nil, nil
+	}
 	name := naming.MonitoringService(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
 	labels[monitoringLabel] = valueExists
@@ -72,7 +76,7 @@ func MonitoringService(params manifests.Params) (*corev1.Service, error) {
 		return nil, err
 	}
 
-	_, metricsPort, err := params.OtelCol.Spec.Config.Service.MetricsEndpoint(params.Log)
+	metricsPort, err := params.OtelCol.Spec.Config.Service.MetricsPort(params.Log)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +120,7 @@ func ExtensionService(params manifests.Params) (*corev1.Service, error) {
 		return nil, nil
 	}
 
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
@@ -127,7 +131,9 @@ func ExtensionService(params manifests.Params) (*corev1.Service, error) {
 			Ports:    ports,
 			Selector: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
 		},
-	}, nil
+	}
+	clearUnallocatedNodePorts(svc)
+	return svc, nil
 }
 
 func Service(params manifests.Params) (*corev1.Service, error) {
@@ -185,7 +191,7 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		trafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
 	}
 
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Service(params.OtelCol.Name),
 			Namespace:   params.OtelCol.Namespace,
@@ -200,7 +206,20 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 			IPFamilies:            params.OtelCol.Spec.IpFamilies,
 			IPFamilyPolicy:        params.OtelCol.Spec.IpFamilyPolicy,
 		},
-	}, nil
+	}
+	clearUnallocatedNodePorts(svc)
+	return svc, nil
+}
+
+// clearUnallocatedNodePorts clears the node ports pinned by the components' configs unless the service allocates node
+// ports, the API server rejects them on any other type of service.
+func clearUnallocatedNodePorts(svc *corev1.Service) {
+	if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		return
+	}
+	for i := range svc.Spec.Ports {
+		svc.Spec.Ports[i].NodePort = 0
+	}
 }
 
 type PortNumberKey struct {
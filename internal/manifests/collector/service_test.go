@@ -258,6 +258,45 @@ func TestDesiredService(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should not set the node ports on a cluster IP service", func(t *testing.T) {
+		params := manifests.Params{
+			Config: config.Config{},
+			Log:    logger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{
+						Object: map[string]interface{}{
+							"otlp": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{
+										"node_port": 30317,
+									},
+								},
+							},
+						},
+					},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {
+								Receivers: []string{"otlp"},
+							},
+						},
+					},
+				}},
+			},
+		}
+
+		actual, err := Service(params)
+		assert.NoError(t, err)
+		assert.Len(t, actual.Spec.Ports, 1)
+		assert.Zero(t, actual.Spec.Ports[0].NodePort)
+
+		headless, err := HeadlessService(params)
+		assert.NoError(t, err)
+		assert.Len(t, headless.Spec.Ports, 1)
+		assert.Zero(t, headless.Spec.Ports[0].NodePort)
+	})
+
 }
 
 func TestHeadlessService(t *testing.T) {
@@ -309,6 +348,25 @@ func TestMonitoringService(t *testing.T) {
 		assert.NotNil(t, actual)
 		assert.Equal(t, expected, actual.Spec.Ports)
 	})
+
+	t.Run("no service when the metrics are disabled", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.Config = v1beta1.Config{
+			Service: v1beta1.Service{
+				Telemetry: &v1beta1.AnyConfig{
+					Object: map[string]interface{}{
+						"metrics": map[string]interface{}{
+							"level": "none",
+						},
+					},
+				},
+			},
+		}
+
+		actual, err := MonitoringService(params)
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
 }
 
 func TestExtensionService(t *testing.T) {
@@ -30,6 +30,10 @@ func ServiceMonitor(params manifests.Params) (*monitoringv1.ServiceMonitor, erro
 
 // ServiceMonitor returns the service monitor for the monitoring service of the collector.
 func ServiceMonitorMonitoring(params manifests.Params) (*monitoringv1.ServiceMonitor, error) {
+	if !params.OtelCol.Spec.Config.Service.MetricsEnabled() {
+		// There's no monitoring service when the collector doesn't expose its metrics.
+		return nil, nil
+	}
 	name := naming.ServiceMonitor(fmt.Sprintf("%s-monitoring", params.OtelCol.Name))
 	endpoints := []monitoringv1.Endpoint{
 		{